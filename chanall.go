@@ -1,7 +1,11 @@
 package iterhelper
 
 import (
+	"context"
 	"iter"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
 )
 
 // ChanAll returns an [iterator] over the elements of the [channel].
@@ -41,3 +45,107 @@ func ChanAll2[E any](c <-chan E) iter.Seq2[int, E] {
 		}
 	}
 }
+
+// ChanAllCtx returns an [iterator] over the elements of the [channel].
+// The sequence ends when the [channel] is closed or 'ctx' is done.
+// If 'c' is nil, [iterator] over the empty sequence is returned.
+//
+// [channel]: https://go.dev/ref/spec#Channel_types
+// [iterator]: https://pkg.go.dev/iter#Seq
+func ChanAllCtx[E any](ctx context.Context, c <-chan E) iter.Seq[E] {
+	if c == nil {
+		return Empty[E]()
+	}
+	return func(yield func(E) bool) {
+		for {
+			// checked before the receive, since select picks randomly among ready cases
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-c:
+				if !ok || !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ChanAll2Ctx returns an [iterator] over index-element pairs of the [channel].
+// The sequence ends when the [channel] is closed or 'ctx' is done.
+// If 'c' is nil, [iterator] over the empty sequence of pairs is returned.
+//
+// [channel]: https://go.dev/ref/spec#Channel_types
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func ChanAll2Ctx[E any](ctx context.Context, c <-chan E) iter.Seq2[int, E] {
+	if c == nil {
+		return Empty2[int, E]()
+	}
+	return func(yield func(int, E) bool) {
+		i := 0
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-c:
+				if !ok || !yield(i, e) {
+					return
+				}
+				i++
+			}
+		}
+	}
+}
+
+// ChanAllErr returns a [SeqErr] over the elements of the [channel].
+// The sequence ends when the [channel] is closed or 'ctx' is done.
+// In the latter case the zero value and 'ctx' error are yielded last.
+// If 'c' is nil, [SeqErr] over the empty sequence is returned.
+//
+// [channel]: https://go.dev/ref/spec#Channel_types
+func ChanAllErr[E any](ctx context.Context, c <-chan E) SeqErr[E] {
+	if c == nil {
+		return Empty2[E, error]()
+	}
+	return func(yield func(E, error) bool) {
+		for {
+			if ctx.Err() != nil {
+				yield(generichelper.ZeroValue[E](), errorhelper.CallerError(ctx.Err()))
+				return
+			}
+			select {
+			case <-ctx.Done():
+				yield(generichelper.ZeroValue[E](), errorhelper.CallerError(ctx.Err()))
+				return
+			case e, ok := <-c:
+				if !ok || !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ChanAllSized returns a [Sized] over the elements of the [channel].
+// The number of elements buffered in the [channel] at the moment of the call is used as the length hint.
+// If 'c' is nil, [Sized] over the empty sequence is returned.
+//
+// [channel]: https://go.dev/ref/spec#Channel_types
+func ChanAllSized[E any](c <-chan E) Sized[E] {
+	return NewSized(ChanAll(c), len(c))
+}
+
+// ChanAll2Sized returns a [Sized2] over index-element pairs of the [channel].
+// The number of elements buffered in the [channel] at the moment of the call is used as the length hint.
+// If 'c' is nil, [Sized2] over the empty sequence of pairs is returned.
+//
+// [channel]: https://go.dev/ref/spec#Channel_types
+func ChanAll2Sized[E any](c <-chan E) Sized2[int, E] {
+	return NewSized2(ChanAll2(c), len(c))
+}
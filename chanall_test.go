@@ -1,8 +1,14 @@
 package iterhelper
 
 import (
+	"context"
+	"errors"
 	"iter"
+	"slices"
 	"testing"
+	"time"
+
+	"github.com/solsw/generichelper"
 )
 
 func closedCh() chan int {
@@ -81,3 +87,107 @@ func TestChanAll_int_2(t *testing.T) {
 		}
 	})
 }
+
+func TestChanAllCtx_int(t *testing.T) {
+	type args struct {
+		c <-chan int
+	}
+	tests := []struct {
+		name string
+		args args
+		want iter.Seq[int]
+	}{
+		{name: "nil channel",
+			args: args{c: nil},
+			want: Empty[int](),
+		},
+		{name: "closed channel",
+			args: args{c: closedCh()},
+			want: Empty[int](),
+		},
+		{name: "3",
+			args: args{c: chn3()},
+			want: Var(4, 3, 2, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ChanAllCtx(context.Background(), tt.args.c)
+			equal, _ := Equal(got, tt.want)
+			if !equal {
+				t.Errorf("ChanAllCtx() = %v, want %v", StringDef(got), StringDef(tt.want))
+			}
+		})
+	}
+}
+
+func TestChanAllCtx_idle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	// the channel is never closed
+	got := ChanAllCtx(ctx, make(chan int))
+	equal, _ := Equal(got, Empty[int]())
+	if !equal {
+		t.Errorf("ChanAllCtx() = %v, want %v", StringDef(got), StringDef(Empty[int]()))
+	}
+}
+
+// bufferedCh returns a closed channel with 'n' buffered elements.
+func bufferedCh(n int) <-chan int {
+	c := make(chan int, n)
+	for i := range n {
+		c <- i
+	}
+	close(c)
+	return c
+}
+
+func TestChanAllCtx_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := slices.Collect(ChanAllCtx(ctx, bufferedCh(100))); len(got) != 0 {
+		t.Errorf("ChanAllCtx() = %v, want %v", got, []int{})
+	}
+	if got := Collect2Tuple(ChanAll2Ctx(ctx, bufferedCh(100))); len(got) != 0 {
+		t.Errorf("ChanAll2Ctx() = %v, want %v", got, []int{})
+	}
+	got, err := TryCollect(ChanAllErr(ctx, bufferedCh(100)))
+	if !errors.Is(err, context.Canceled) || len(got) != 0 {
+		t.Errorf("ChanAllErr() = %v, %v, want %v, %v", got, err, []int{}, context.Canceled)
+	}
+}
+
+func TestChanAll2Ctx_int(t *testing.T) {
+	got := ChanAll2Ctx(context.Background(), chn3())
+	want := Var2Tuple(
+		generichelper.NewTuple2(0, 4),
+		generichelper.NewTuple2(1, 3),
+		generichelper.NewTuple2(2, 2),
+		generichelper.NewTuple2(3, 1),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("ChanAll2Ctx() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+}
+
+func TestChanAllErr_int(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := make(chan int)
+	go func() {
+		c <- 1
+		c <- 2
+		cancel()
+	}()
+	got, err := TryCollect(ChanAllErr(ctx, c))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ChanAllErr() error = %v, expectedErr %v", err, context.Canceled)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("ChanAllErr() = %v, want %v", got, []int{1, 2})
+	}
+	got, err = TryCollect(ChanAllErr(context.Background(), chn3()))
+	if err != nil || len(got) != 4 {
+		t.Errorf("ChanAllErr() = %v, %v, want %v", got, err, []int{4, 3, 2, 1})
+	}
+}
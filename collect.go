@@ -1,8 +1,11 @@
 package iterhelper
 
 import (
+	"fmt"
 	"iter"
+	"slices"
 
+	"github.com/solsw/errorhelper"
 	"github.com/solsw/generichelper"
 )
 
@@ -36,3 +39,273 @@ func Collect2Tuple[K, V any](seq2 iter.Seq2[K, V]) []generichelper.Tuple2[K, V]
 	}
 	return r
 }
+
+// CollectSized returns a slice of values collected from the [Sized].
+// The slice is preallocated according to the length hint of 's' (up to a limit).
+// If 's' yields no values, nil is returned.
+func CollectSized[V any](s Sized[V]) []V {
+	var r []V
+	if n := preallocLen(s.Len(), 1); n > 0 {
+		r = make([]V, 0, n)
+	}
+	r = slices.AppendSeq(r, s.All())
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
+
+// Collect2Sized returns a slice of values collected from the [Sized2].
+// Each pair of values yielded by 's' results in two values in the slice.
+// The slice is preallocated according to the length hint of 's' (up to a limit).
+// If 's' yields no values, nil is returned.
+func Collect2Sized[K, V any](s Sized2[K, V]) []any {
+	var r []any
+	if n := preallocLen(s.Len(), 2); n > 0 {
+		r = make([]any, 0, n)
+	}
+	for k, v := range s.All() {
+		r = append(r, k, v)
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
+
+// Collect2TupleSized returns a slice of tuples of values collected from the [Sized2].
+// The slice is preallocated according to the length hint of 's' (up to a limit).
+// If 's' yields no values, nil is returned.
+func Collect2TupleSized[K, V any](s Sized2[K, V]) []generichelper.Tuple2[K, V] {
+	var r []generichelper.Tuple2[K, V]
+	if n := preallocLen(s.Len(), 1); n > 0 {
+		r = make([]generichelper.Tuple2[K, V], 0, n)
+	}
+	for k, v := range s.All() {
+		r = append(r, generichelper.Tuple2[K, V]{Item1: k, Item2: v})
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
+
+// DuplicatePolicy defines the behavior of [CollectMap] on a duplicate key.
+type DuplicatePolicy int
+
+const (
+	// DuplicateKeepFirst keeps the value that came first.
+	DuplicateKeepFirst DuplicatePolicy = iota
+	// DuplicateKeepLast keeps the value that came last.
+	DuplicateKeepLast
+	// DuplicateError makes [CollectMap] fail with [ErrDuplicateKey].
+	DuplicateError
+)
+
+// CollectMap returns a map of pairs of values collected from the [iterator].
+// Duplicate keys are handled according to 'policy'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func CollectMap[K comparable, V any](seq2 iter.Seq2[K, V], policy DuplicatePolicy) (map[K]V, error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if policy < DuplicateKeepFirst || policy > DuplicateError {
+		return nil, errorhelper.CallerError(ErrUnknownPolicy)
+	}
+	r := make(map[K]V)
+	for k, v := range seq2 {
+		if _, ok := r[k]; ok {
+			switch policy {
+			case DuplicateKeepFirst:
+				continue
+			case DuplicateError:
+				return nil, errorhelper.CallerError(fmt.Errorf("%w: %v", ErrDuplicateKey, k))
+			}
+		}
+		r[k] = v
+	}
+	return r, nil
+}
+
+// CollectMapMerge returns a map of pairs of values collected from the [iterator].
+// On a duplicate key the value in the map is replaced by the result of 'merge'
+// called with the key, the value in the map and the new value.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func CollectMapMerge[K comparable, V any](seq2 iter.Seq2[K, V], merge func(k K, old, new V) V) (map[K]V, error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if merge == nil {
+		return nil, errorhelper.CallerError(ErrNilMerge)
+	}
+	r := make(map[K]V)
+	for k, v := range seq2 {
+		if old, ok := r[k]; ok {
+			v = merge(k, old, v)
+		}
+		r[k] = v
+	}
+	return r, nil
+}
+
+// CollectMultiMap returns a map of keys to all the values paired with them by the [iterator].
+// Values of each key are in the order they were yielded.
+// If 'seq2' is nil, nil is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func CollectMultiMap[K comparable, V any](seq2 iter.Seq2[K, V]) map[K][]V {
+	if seq2 == nil {
+		return nil
+	}
+	r := make(map[K][]V)
+	for k, v := range seq2 {
+		r[k] = append(r[k], v)
+	}
+	return r
+}
+
+// GroupBy returns an [iterator] over keys returned by 'keySelector' for values yielded by 'seq'
+// paired with the values having the key.
+// Keys are yielded in the order of their first appearance,
+// values of each key are in the order they were yielded.
+// 'seq' is entirely consumed before the first pair is yielded.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func GroupBy[V any, K comparable](seq iter.Seq[V], keySelector func(V) K) (iter.Seq2[K, []V], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if keySelector == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	return func(yield func(K, []V) bool) {
+			var keys []K
+			groups := make(map[K][]V)
+			for v := range seq {
+				k := keySelector(v)
+				if _, ok := groups[k]; !ok {
+					keys = append(keys, k)
+				}
+				groups[k] = append(groups[k], v)
+			}
+			for _, k := range keys {
+				if !yield(k, groups[k]) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// GroupBy2 returns an [iterator] over keys returned by 'keySelector' for pairs yielded by 'seq2'
+// paired with tuples of the pairs having the key.
+// Keys are yielded in the order of their first appearance,
+// tuples of each key are in the order the pairs were yielded.
+// 'seq2' is entirely consumed before the first pair is yielded.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func GroupBy2[K, V any, G comparable](seq2 iter.Seq2[K, V], keySelector func(K, V) G) (iter.Seq2[G, []generichelper.Tuple2[K, V]], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if keySelector == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	return func(yield func(G, []generichelper.Tuple2[K, V]) bool) {
+			var keys []G
+			groups := make(map[G][]generichelper.Tuple2[K, V])
+			for k, v := range seq2 {
+				g := keySelector(k, v)
+				if _, ok := groups[g]; !ok {
+					keys = append(keys, g)
+				}
+				groups[g] = append(groups[g], generichelper.NewTuple2(k, v))
+			}
+			for _, g := range keys {
+				if !yield(g, groups[g]) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// Partition returns values yielded by the [iterator] that satisfy 'predicate'
+// and values that do not.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func Partition[V any](seq iter.Seq[V], predicate func(V) bool) (matching, rest []V, err error) {
+	if seq == nil {
+		return nil, nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if predicate == nil {
+		return nil, nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	for v := range seq {
+		if predicate(v) {
+			matching = append(matching, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matching, rest, nil
+}
+
+// Partition2 returns tuples of pairs of values yielded by the [iterator] that satisfy 'predicate'
+// and tuples of pairs that do not.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func Partition2[K, V any](seq2 iter.Seq2[K, V], predicate func(K, V) bool) (matching, rest []generichelper.Tuple2[K, V], err error) {
+	if seq2 == nil {
+		return nil, nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if predicate == nil {
+		return nil, nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	for k, v := range seq2 {
+		if predicate(k, v) {
+			matching = append(matching, generichelper.NewTuple2(k, v))
+		} else {
+			rest = append(rest, generichelper.NewTuple2(k, v))
+		}
+	}
+	return matching, rest, nil
+}
+
+// CountBy returns a map of keys returned by 'keySelector' for values yielded by the [iterator]
+// to numbers of values having the key.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func CountBy[V any, K comparable](seq iter.Seq[V], keySelector func(V) K) (map[K]int, error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if keySelector == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	r := make(map[K]int)
+	for v := range seq {
+		r[keySelector(v)]++
+	}
+	return r, nil
+}
+
+// CountBy2 returns a map of keys returned by 'keySelector' for pairs yielded by the [iterator]
+// to numbers of pairs having the key.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func CountBy2[K, V any, G comparable](seq2 iter.Seq2[K, V], keySelector func(K, V) G) (map[G]int, error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if keySelector == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	r := make(map[G]int)
+	for k, v := range seq2 {
+		r[keySelector(k, v)]++
+	}
+	return r, nil
+}
@@ -1,6 +1,7 @@
 package iterhelper
 
 import (
+	"errors"
 	"iter"
 	"reflect"
 	"testing"
@@ -62,3 +63,236 @@ func TestCollect2Tuple(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectMap(t *testing.T) {
+	dup := func() iter.Seq2[string, int] {
+		return errorhelper.Must(Var2[string, int]("a", 1, "b", 2, "a", 3))
+	}
+	tests := []struct {
+		name        string
+		seq2        iter.Seq2[string, int]
+		policy      DuplicatePolicy
+		want        map[string]int
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:        "NilSource",
+			seq2:        nil,
+			policy:      DuplicateKeepFirst,
+			wantErr:     true,
+			expectedErr: ErrNilSec2,
+		},
+		{
+			name:        "UnknownPolicy",
+			seq2:        dup(),
+			policy:      DuplicatePolicy(42),
+			wantErr:     true,
+			expectedErr: ErrUnknownPolicy,
+		},
+		{
+			name:   "Empty",
+			seq2:   Empty2[string, int](),
+			policy: DuplicateError,
+			want:   map[string]int{},
+		},
+		{
+			name:   "KeepFirst",
+			seq2:   dup(),
+			policy: DuplicateKeepFirst,
+			want:   map[string]int{"a": 1, "b": 2},
+		},
+		{
+			name:   "KeepLast",
+			seq2:   dup(),
+			policy: DuplicateKeepLast,
+			want:   map[string]int{"a": 3, "b": 2},
+		},
+		{
+			name:        "Error",
+			seq2:        dup(),
+			policy:      DuplicateError,
+			wantErr:     true,
+			expectedErr: ErrDuplicateKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CollectMap(tt.seq2, tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CollectMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("CollectMap() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CollectMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectMapMerge(t *testing.T) {
+	seq2 := errorhelper.Must(Var2[string, int]("a", 1, "b", 2, "a", 3, "a", 4))
+	got, err := CollectMapMerge(seq2, func(_ string, old, new int) int { return old + new })
+	if err != nil {
+		t.Fatalf("CollectMapMerge() error = %v", err)
+	}
+	if want := map[string]int{"a": 8, "b": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectMapMerge() = %v, want %v", got, want)
+	}
+	if _, err := CollectMapMerge(seq2, nil); !errors.Is(err, ErrNilMerge) {
+		t.Errorf("CollectMapMerge() error = %v, expectedErr %v", err, ErrNilMerge)
+	}
+}
+
+func TestCollectMultiMap(t *testing.T) {
+	tests := []struct {
+		name string
+		seq2 iter.Seq2[string, int]
+		want map[string][]int
+	}{
+		{
+			name: "Nil",
+			seq2: nil,
+			want: nil,
+		},
+		{
+			name: "NonEmpty",
+			seq2: errorhelper.Must(Var2[string, int]("a", 1, "b", 2, "a", 3)),
+			want: map[string][]int{"a": {1, 3}, "b": {2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CollectMultiMap(tt.seq2)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CollectMultiMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	tests := []struct {
+		name        string
+		seq         iter.Seq[string]
+		keySelector func(string) int
+		want        []generichelper.Tuple2[int, []string]
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:        "NilSource",
+			seq:         nil,
+			keySelector: func(s string) int { return len(s) },
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{
+			name:        "NilSelector",
+			seq:         Var("a"),
+			keySelector: nil,
+			wantErr:     true,
+			expectedErr: ErrNilSelector,
+		},
+		{
+			name:        "Empty",
+			seq:         Empty[string](),
+			keySelector: func(s string) int { return len(s) },
+			want:        nil,
+		},
+		{
+			name:        "NonEmpty",
+			seq:         Var("three", "one", "two", "four", "five", "six"),
+			keySelector: func(s string) int { return len(s) },
+			want: []generichelper.Tuple2[int, []string]{
+				{Item1: 5, Item2: []string{"three"}},
+				{Item1: 3, Item2: []string{"one", "two", "six"}},
+				{Item1: 4, Item2: []string{"four", "five"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GroupBy(tt.seq, tt.keySelector)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GroupBy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("GroupBy() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if gotTuples := Collect2Tuple(got); !reflect.DeepEqual(gotTuples, tt.want) {
+				t.Errorf("GroupBy() = %v, want %v", gotTuples, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupBy2(t *testing.T) {
+	got, err := GroupBy2(sec2_int_string(5), func(i int, _ string) bool { return i%2 == 0 })
+	if err != nil {
+		t.Fatalf("GroupBy2() error = %v", err)
+	}
+	tuple := generichelper.NewTuple2[int, string]
+	want := []generichelper.Tuple2[bool, []generichelper.Tuple2[int, string]]{
+		{Item1: true, Item2: []generichelper.Tuple2[int, string]{tuple(0, "0"), tuple(2, "2"), tuple(4, "4")}},
+		{Item1: false, Item2: []generichelper.Tuple2[int, string]{tuple(1, "1"), tuple(3, "3")}},
+	}
+	if gotTuples := Collect2Tuple(got); !reflect.DeepEqual(gotTuples, want) {
+		t.Errorf("GroupBy2() = %v, want %v", gotTuples, want)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	matching, rest, err := Partition(intSeq(1, 6), func(i int) bool { return i%2 == 0 })
+	if err != nil {
+		t.Fatalf("Partition() error = %v", err)
+	}
+	if !reflect.DeepEqual(matching, []int{2, 4, 6}) || !reflect.DeepEqual(rest, []int{1, 3, 5}) {
+		t.Errorf("Partition() = %v, %v, want %v, %v", matching, rest, []int{2, 4, 6}, []int{1, 3, 5})
+	}
+	if _, _, err := Partition(intSeq(1, 6), nil); !errors.Is(err, ErrNilPredicate) {
+		t.Errorf("Partition() error = %v, expectedErr %v", err, ErrNilPredicate)
+	}
+}
+
+func TestPartition2(t *testing.T) {
+	matching, rest, err := Partition2(sec2_int_string(3), func(i int, _ string) bool { return i > 0 })
+	if err != nil {
+		t.Fatalf("Partition2() error = %v", err)
+	}
+	wantMatching := []generichelper.Tuple2[int, string]{{Item1: 1, Item2: "1"}, {Item1: 2, Item2: "2"}}
+	wantRest := []generichelper.Tuple2[int, string]{{Item1: 0, Item2: "0"}}
+	if !reflect.DeepEqual(matching, wantMatching) || !reflect.DeepEqual(rest, wantRest) {
+		t.Errorf("Partition2() = %v, %v, want %v, %v", matching, rest, wantMatching, wantRest)
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	got, err := CountBy(Var("three", "one", "two", "four", "five", "six"), func(s string) int { return len(s) })
+	if err != nil {
+		t.Fatalf("CountBy() error = %v", err)
+	}
+	if want := map[int]int{3: 3, 4: 2, 5: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountBy() = %v, want %v", got, want)
+	}
+}
+
+func TestCountBy2(t *testing.T) {
+	got, err := CountBy2(sec2_int_string(5), func(i int, _ string) bool { return i%2 == 0 })
+	if err != nil {
+		t.Fatalf("CountBy2() error = %v", err)
+	}
+	if want := map[bool]int{true: 3, false: 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountBy2() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,122 @@
+package iterhelper
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/solsw/errorhelper"
+)
+
+// Compare lexicographically compares sequences yielded by two [iterator]s
+// using [cmp.Compare] on their elements.
+// The result is 0 if first == second, -1 if first < second, and +1 if first > second.
+// A sequence that is a prefix of the other one is the lesser.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func Compare[V cmp.Ordered](first, second iter.Seq[V]) (int, error) {
+	if first == nil || second == nil {
+		return 0, errorhelper.CallerError(ErrNilSec)
+	}
+	return compareSeq(first, second, cmp.Compare[V]), nil
+}
+
+// CompareFunc lexicographically compares sequences yielded by two [iterator]s
+// using a specified function on their elements.
+// The result is the first non-zero result of 'compare'.
+// If one sequence is a prefix of the other one, the shorter sequence is the lesser.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func CompareFunc[V any](first, second iter.Seq[V], compare func(V, V) int) (int, error) {
+	if first == nil || second == nil {
+		return 0, errorhelper.CallerError(ErrNilSec)
+	}
+	if compare == nil {
+		return 0, errorhelper.CallerError(ErrNilCompare)
+	}
+	return compareSeq(first, second, compare), nil
+}
+
+// Compare2 lexicographically compares sequences yielded by two [iterator]s
+// using [cmp.Compare] on keys and then on values of their pairs.
+// The result is 0 if first == second, -1 if first < second, and +1 if first > second.
+// A sequence that is a prefix of the other one is the lesser.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func Compare2[K, V cmp.Ordered](first, second iter.Seq2[K, V]) (int, error) {
+	if first == nil || second == nil {
+		return 0, errorhelper.CallerError(ErrNilSec2)
+	}
+	return compareSeq2(first, second,
+			func(k1 K, v1 V, k2 K, v2 V) int {
+				return cmp.Or(cmp.Compare(k1, k2), cmp.Compare(v1, v2))
+			}),
+		nil
+}
+
+// CompareFunc2 lexicographically compares sequences yielded by two [iterator]s
+// using a specified function on their pairs.
+// The result is the first non-zero result of 'compare'.
+// If one sequence is a prefix of the other one, the shorter sequence is the lesser.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func CompareFunc2[K, V any](first, second iter.Seq2[K, V],
+	compare func(k1 K, v1 V, k2 K, v2 V) int) (int, error) {
+	if first == nil || second == nil {
+		return 0, errorhelper.CallerError(ErrNilSec2)
+	}
+	if compare == nil {
+		return 0, errorhelper.CallerError(ErrNilCompare)
+	}
+	return compareSeq2(first, second, compare), nil
+}
+
+// compareSeq walks 'first' and 'second' in lockstep
+// until 'compare' returns a non-zero result or either sequence ends.
+// Only 'second' is pulled, 'first' is ranged over.
+func compareSeq[V any](first, second iter.Seq[V], compare func(V, V) int) int {
+	next2, stop2 := iter.Pull(second)
+	defer stop2()
+	for v1 := range first {
+		v2, ok2 := next2()
+		if !ok2 {
+			return compareEnds(true, false)
+		}
+		if c := compare(v1, v2); c != 0 {
+			return c
+		}
+	}
+	_, ok2 := next2()
+	return compareEnds(false, ok2)
+}
+
+// compareSeq2 walks 'first' and 'second' in lockstep
+// until 'compare' returns a non-zero result or either sequence ends.
+// Only 'second' is pulled, 'first' is ranged over.
+func compareSeq2[K, V any](first, second iter.Seq2[K, V], compare func(k1 K, v1 V, k2 K, v2 V) int) int {
+	next2, stop2 := iter.Pull2(second)
+	defer stop2()
+	for k1, v1 := range first {
+		k2, v2, ok2 := next2()
+		if !ok2 {
+			return compareEnds(true, false)
+		}
+		if c := compare(k1, v1, k2, v2); c != 0 {
+			return c
+		}
+	}
+	_, _, ok2 := next2()
+	return compareEnds(false, ok2)
+}
+
+// compareEnds compares sequences at least one of which has ended:
+// the ended sequence is the lesser.
+func compareEnds(ok1, ok2 bool) int {
+	switch {
+	case ok1 == ok2:
+		return 0
+	case ok1:
+		return +1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,149 @@
+package iterhelper
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestCompare_int(t *testing.T) {
+	type args struct {
+		first  iter.Seq[int]
+		second iter.Seq[int]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        int
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilFirst",
+			args:        args{first: nil, second: Var(1)},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "EmptyEmpty",
+			args: args{first: Empty[int](), second: Empty[int]()},
+			want: 0,
+		},
+		{name: "EmptyFirst",
+			args: args{first: Empty[int](), second: Var(1)},
+			want: -1,
+		},
+		{name: "EmptySecond",
+			args: args{first: Var(1), second: Empty[int]()},
+			want: +1,
+		},
+		{name: "Equal",
+			args: args{first: Var(1, 2, 3), second: intSeq(1, 3)},
+			want: 0,
+		},
+		{name: "Less",
+			args: args{first: Var(1, 2, 3), second: Var(1, 3)},
+			want: -1,
+		},
+		{name: "Greater",
+			args: args{first: Var(1, 3), second: Var(1, 2, 3)},
+			want: +1,
+		},
+		{name: "Prefix",
+			args: args{first: Var(1, 2), second: Var(1, 2, 3)},
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compare(tt.args.first, tt.args.second)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Compare() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Compare() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Compare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareFunc_string(t *testing.T) {
+	caseInsensitiveCompare := func(x, y string) int {
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+	}
+	got, err := CompareFunc(Var("a", "B", "c"), Var("A", "b", "C"), caseInsensitiveCompare)
+	if err != nil || got != 0 {
+		t.Errorf("CompareFunc() = %v, %v, want %v", got, err, 0)
+	}
+	got, _ = CompareFunc(Var("a", "B"), Var("A", "c"), caseInsensitiveCompare)
+	if got != -1 {
+		t.Errorf("CompareFunc() = %v, want %v", got, -1)
+	}
+	if _, err := CompareFunc(Var("a"), Var("a"), nil); !errors.Is(err, ErrNilCompare) {
+		t.Errorf("CompareFunc() error = %v, expectedErr %v", err, ErrNilCompare)
+	}
+}
+
+func TestCompare2_int_string(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  iter.Seq2[int, string]
+		second iter.Seq2[int, string]
+		want   int
+	}{
+		{name: "Equal",
+			first:  sec2_int_string(3),
+			second: sec2_int_string(3),
+			want:   0,
+		},
+		{name: "Shorter",
+			first:  sec2_int_string(2),
+			second: sec2_int_string(3),
+			want:   -1,
+		},
+		{name: "GreaterValue",
+			first:  Var2Tuple(generichelper.NewTuple2(0, "b")),
+			second: Var2Tuple(generichelper.NewTuple2(0, "a"), generichelper.NewTuple2(1, "a")),
+			want:   +1,
+		},
+		{name: "LesserKey",
+			first:  Var2Tuple(generichelper.NewTuple2(0, "b")),
+			second: Var2Tuple(generichelper.NewTuple2(1, "a")),
+			want:   -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compare2(tt.first, tt.second)
+			if err != nil {
+				t.Fatalf("Compare2() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Compare2() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareFunc2_int_string(t *testing.T) {
+	byValue := func(_ int, v1 string, _ int, v2 string) int { return cmp.Compare(v1, v2) }
+	got, err := CompareFunc2(
+		Var2Tuple(generichelper.NewTuple2(5, "a")),
+		Var2Tuple(generichelper.NewTuple2(1, "a")),
+		byValue)
+	if err != nil || got != 0 {
+		t.Errorf("CompareFunc2() = %v, %v, want %v", got, err, 0)
+	}
+	if _, err := CompareFunc2(sec2_int_string(1), nil, byValue); !errors.Is(err, ErrNilSec2) {
+		t.Errorf("CompareFunc2() error = %v, expectedErr %v", err, ErrNilSec2)
+	}
+}
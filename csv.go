@@ -0,0 +1,116 @@
+package iterhelper
+
+import (
+	"encoding/csv"
+	"io"
+	"iter"
+
+	"github.com/solsw/errorhelper"
+)
+
+// CSVRecords returns an [iterator] over records read from 'r' by [csv.Reader] with default settings
+// and a function returning the error that ended the iteration.
+// See [CSVReaderRecords] for details.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func CSVRecords(r io.Reader) (iter.Seq2[int, []string], func() error, error) {
+	if r == nil {
+		return nil, nil, errorhelper.CallerError(ErrNilReader)
+	}
+	return CSVReaderRecords(csv.NewReader(r))
+}
+
+// CSVReaderRecords returns an [iterator] over records read by 'cr'
+// and a function returning the error that ended the iteration.
+// The [iterator] yields the line number where a record starts along with the record.
+// Records are read as they are pulled, so the [iterator] can be iterated only once.
+// If 'cr' ReuseRecord is true, a yielded record is valid only until the next one is pulled.
+// The [iterator] stops at the first read error (e.g. [csv.ParseError]).
+// The returned function must be called after the iteration is over;
+// it returns nil if the input has been exhausted without errors.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func CSVReaderRecords(cr *csv.Reader) (iter.Seq2[int, []string], func() error, error) {
+	if cr == nil {
+		return nil, nil, errorhelper.CallerError(ErrNilReader)
+	}
+	var last error
+	return func(yield func(int, []string) bool) {
+			last = nil
+			for {
+				record, err := cr.Read()
+				if err != nil {
+					if err != io.EOF {
+						last = err
+					}
+					return
+				}
+				line, _ := cr.FieldPos(0)
+				if !yield(line, record) {
+					return
+				}
+			}
+		},
+		func() error {
+			return errorhelper.CallerError(last)
+		},
+		nil
+}
+
+// WriteCSV writes records yielded by the [iterator] to 'cw' and flushes it.
+// The number of records written and the first error, if any, are returned.
+// No further records are pulled from the [iterator] after an error.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func WriteCSV(cw *csv.Writer, seq iter.Seq[[]string]) (int, error) {
+	if cw == nil {
+		return 0, errorhelper.CallerError(ErrNilWriter)
+	}
+	if seq == nil {
+		return 0, errorhelper.CallerError(ErrNilSec)
+	}
+	n, err := writeCSV(cw, seq, nil, func(record []string) ([]string, error) { return record, nil })
+	return n, errorhelper.CallerError(err)
+}
+
+// WriteCSVFunc writes 'header', if it is not nil, and then values yielded by the [iterator]
+// converted to records by 'fields' to 'cw' and flushes it.
+// The number of records written (not including 'header') and the first error, if any, are returned.
+// No further values are pulled from the [iterator] after an error, including the one returned by 'fields'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func WriteCSVFunc[V any](cw *csv.Writer, seq iter.Seq[V], header []string, fields func(V) ([]string, error)) (int, error) {
+	if cw == nil {
+		return 0, errorhelper.CallerError(ErrNilWriter)
+	}
+	if seq == nil {
+		return 0, errorhelper.CallerError(ErrNilSec)
+	}
+	if fields == nil {
+		return 0, errorhelper.CallerError(ErrNilSelector)
+	}
+	n, err := writeCSV(cw, seq, header, fields)
+	return n, errorhelper.CallerError(err)
+}
+
+func writeCSV[V any](cw *csv.Writer, seq iter.Seq[V], header []string, fields func(V) ([]string, error)) (int, error) {
+	if header != nil {
+		if err := cw.Write(header); err != nil {
+			return 0, err
+		}
+	}
+	n := 0
+	for v := range seq {
+		record, err := fields(v)
+		if err != nil {
+			cw.Flush()
+			return n, err
+		}
+		if err := cw.Write(record); err != nil {
+			return n, err
+		}
+		n++
+	}
+	cw.Flush()
+	return n, cw.Error()
+}
@@ -0,0 +1,211 @@
+package iterhelper
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCSVRecords(t *testing.T) {
+	if _, _, err := CSVRecords(nil); !errors.Is(err, ErrNilReader) {
+		t.Errorf("CSVRecords() error = %v, expectedErr %v", err, ErrNilReader)
+	}
+	tests := []struct {
+		name      string
+		input     string
+		wantLines []int
+		want      [][]string
+		wantErr   error
+	}{
+		{name: "Empty",
+			input: "",
+		},
+		{name: "Regular",
+			input:     "a,b\n\n\"c\nd\",e\nf,g\n",
+			wantLines: []int{1, 3, 5},
+			want:      [][]string{{"a", "b"}, {"c\nd", "e"}, {"f", "g"}},
+		},
+		{name: "ParseError",
+			input:     "a,b\nc,\"d\ne,f\n",
+			wantLines: []int{1},
+			want:      [][]string{{"a", "b"}},
+			wantErr:   csv.ErrQuote,
+		},
+		{name: "FieldCount",
+			input:     "a,b\nc\n",
+			wantLines: []int{1},
+			want:      [][]string{{"a", "b"}},
+			wantErr:   csv.ErrFieldCount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq2, errFn, err := CSVRecords(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("CSVRecords() error = %v", err)
+			}
+			var lines []int
+			var records [][]string
+			for line, record := range seq2 {
+				lines = append(lines, line)
+				records = append(records, record)
+			}
+			if !slices.Equal(lines, tt.wantLines) {
+				t.Errorf("CSVRecords() lines = %v, want %v", lines, tt.wantLines)
+			}
+			if !reflect.DeepEqual(records, tt.want) {
+				t.Errorf("CSVRecords() = %q, want %q", records, tt.want)
+			}
+			if err := errFn(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CSVRecords() final error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCSVReaderRecords_break(t *testing.T) {
+	if _, _, err := CSVReaderRecords(nil); !errors.Is(err, ErrNilReader) {
+		t.Errorf("CSVReaderRecords() error = %v, expectedErr %v", err, ErrNilReader)
+	}
+	// the failing rest of input must not be read before it is needed
+	cr := csv.NewReader(io.MultiReader(strings.NewReader("a;1\nb;2\n"), errReader{}))
+	cr.Comma = ';'
+	seq2, errFn, _ := CSVReaderRecords(cr)
+	for line, record := range seq2 {
+		if line != 1 || !slices.Equal(record, []string{"a", "1"}) {
+			t.Errorf("CSVReaderRecords() = %v, %q", line, record)
+		}
+		break
+	}
+	if err := errFn(); err != nil {
+		t.Errorf("CSVReaderRecords() final error = %v", err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b strings.Builder
+	cw := csv.NewWriter(&b)
+	if _, err := WriteCSV(nil, Empty[[]string]()); !errors.Is(err, ErrNilWriter) {
+		t.Errorf("WriteCSV() error = %v, expectedErr %v", err, ErrNilWriter)
+	}
+	if _, err := WriteCSV(cw, nil); !errors.Is(err, ErrNilSec) {
+		t.Errorf("WriteCSV() error = %v, expectedErr %v", err, ErrNilSec)
+	}
+	n, err := WriteCSV(cw, Var([]string{"a", "b,c"}, []string{"d\"e", ""}))
+	if err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if want := "a,\"b,c\"\n\"d\"\"e\",\n"; n != 2 || b.String() != want {
+		t.Errorf("WriteCSV() = %q, %v, want %q, %v", b.String(), n, want, 2)
+	}
+}
+
+func TestWriteCSV_writeError(t *testing.T) {
+	pulled := 0
+	seq := func(yield func([]string) bool) {
+		for i := range 10000 {
+			pulled++
+			if !yield([]string{strconv.Itoa(i)}) {
+				return
+			}
+		}
+	}
+	_, err := WriteCSV(csv.NewWriter(&failingWriter{limit: 10}), seq)
+	if !errors.Is(err, ErrTestError) {
+		t.Errorf("WriteCSV() error = %v, expectedErr %v", err, ErrTestError)
+	}
+	if pulled == 10000 {
+		t.Errorf("WriteCSV() pulled all values after write error")
+	}
+}
+
+func TestWriteCSVFunc(t *testing.T) {
+	type row struct {
+		Name string
+		Age  int
+	}
+	fields := func(r row) ([]string, error) {
+		if r.Age < 0 {
+			return nil, ErrTestError
+		}
+		return []string{r.Name, strconv.Itoa(r.Age)}, nil
+	}
+	tests := []struct {
+		name        string
+		seq         iter.Seq[row]
+		header      []string
+		fields      func(row) ([]string, error)
+		want        string
+		wantN       int
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilFields",
+			seq:         Empty[row](),
+			wantErr:     true,
+			expectedErr: ErrNilSelector,
+		},
+		{name: "EmptyWithHeader",
+			seq:    Empty[row](),
+			header: []string{"name", "age"},
+			fields: fields,
+			want:   "name,age\n",
+		},
+		{name: "Regular",
+			seq:    Var(row{"Ann", 30}, row{"Bob", 4}),
+			header: []string{"name", "age"},
+			fields: fields,
+			want:   "name,age\nAnn,30\nBob,4\n",
+			wantN:  2,
+		},
+		{name: "FieldsError",
+			seq:         Var(row{"Ann", 30}, row{"Bob", -1}, row{"Cid", 5}),
+			fields:      fields,
+			want:        "Ann,30\n",
+			wantN:       1,
+			wantErr:     true,
+			expectedErr: ErrTestError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			n, err := WriteCSVFunc(csv.NewWriter(&b), tt.seq, tt.header, tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WriteCSVFunc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("WriteCSVFunc() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if n != tt.wantN || b.String() != tt.want {
+				t.Errorf("WriteCSVFunc() = %q, %v, want %q, %v", b.String(), n, tt.want, tt.wantN)
+			}
+		})
+	}
+}
+
+func TestWriteCSV_roundTrip(t *testing.T) {
+	want := [][]string{{"a", "b\nc"}, {"\"d\"", ""}, {" e ", "f,g"}}
+	var b strings.Builder
+	if _, err := WriteCSV(csv.NewWriter(&b), slices.Values(want)); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	seq2, errFn, _ := CSVRecords(strings.NewReader(b.String()))
+	var got [][]string
+	for _, record := range seq2 {
+		got = append(got, record)
+	}
+	if err := errFn(); err != nil {
+		t.Fatalf("CSVRecords() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CSVRecords(WriteCSV()) = %q, want %q", got, want)
+	}
+}
@@ -264,3 +264,117 @@ func TestEqual2_int_string(t *testing.T) {
 		})
 	}
 }
+
+// intSequence is a Sequence that is not backed by a slice.
+type intSequence struct {
+	start, count int
+}
+
+func (s intSequence) All() iter.Seq[int] {
+	return intSeq(s.start, s.count)
+}
+
+func TestEqualSequence_int(t *testing.T) {
+	type args struct {
+		first  Sequence[int]
+		second Sequence[int]
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{name: "EmptySlices",
+			args: args{first: SliceSized[int](nil), second: SliceSized([]int{})},
+			want: true,
+		},
+		{name: "EqualSlices",
+			args: args{first: SliceSized([]int{1, 2, 3}), second: SliceSized([]int{1, 2, 3})},
+			want: true,
+		},
+		{name: "UnequalSlices",
+			args: args{first: SliceSized([]int{1, 2, 3}), second: SliceSized([]int{1, 2})},
+			want: false,
+		},
+		{name: "EqualSliceFirst",
+			args: args{first: SliceSized([]int{1, 2, 3}), second: intSequence{1, 3}},
+			want: true,
+		},
+		{name: "ShorterSliceFirst",
+			args: args{first: SliceSized([]int{1, 2}), second: intSequence{1, 3}},
+			want: false,
+		},
+		{name: "LongerSliceSecond",
+			args: args{first: intSequence{1, 3}, second: SliceSized([]int{1, 2, 3, 4})},
+			want: false,
+		},
+		{name: "EqualNoSlices",
+			args: args{first: intSequence{1, 3}, second: intSequence{1, 3}},
+			want: true,
+		},
+		{name: "UnequalNoSlices",
+			args: args{first: intSequence{1, 3}, second: intSequence{2, 3}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EqualSequence(tt.args.first, tt.args.second)
+			if err != nil {
+				t.Fatalf("EqualSequence() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EqualSequence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualEqSequence_string(t *testing.T) {
+	got, err := EqualEqSequence[string](SliceSized([]string{"one", "TWO"}), SliceSized([]string{"ONE", "two"}), caseInsensitiveEqual)
+	if err != nil || !got {
+		t.Errorf("EqualEqSequence() = %v, %v, want %v", got, err, true)
+	}
+	// 'equal' must get the elements of 'first' as its first argument
+	lessOrEqual := func(x, y int) bool { return x <= y }
+	got, _ = EqualEqSequence(intSequence{1, 3}, Sequence[int](SliceSized([]int{2, 3, 4})), lessOrEqual)
+	if !got {
+		t.Errorf("EqualEqSequence() = %v, want %v", got, true)
+	}
+	if _, err := EqualEqSequence(intSequence{1, 3}, nil, lessOrEqual); err == nil {
+		t.Errorf("EqualEqSequence() succeeded unexpectedly")
+	}
+}
+
+const benchmarkLen = 10000
+
+func BenchmarkEqual_Var(b *testing.B) {
+	s := make([]int, benchmarkLen)
+	for b.Loop() {
+		_, _ = Equal(Var(s...), Var(s...))
+	}
+}
+
+func BenchmarkEqualEq_Var(b *testing.B) {
+	s := make([]int, benchmarkLen)
+	equal := func(x, y int) bool { return x == y }
+	for b.Loop() {
+		_, _ = EqualEq(Var(s...), Var(s...), equal)
+	}
+}
+
+func BenchmarkEqualEqSequence_SliceSized(b *testing.B) {
+	s := make([]int, benchmarkLen)
+	equal := func(x, y int) bool { return x == y }
+	for b.Loop() {
+		_, _ = EqualEqSequence[int](SliceSized[int](s), SliceSized[int](s), equal)
+	}
+}
+
+func BenchmarkEqualEqSequence_SliceSizedAndSeq(b *testing.B) {
+	s := make([]int, benchmarkLen)
+	equal := func(x, y int) bool { return x == 0 && y >= 0 }
+	for b.Loop() {
+		_, _ = EqualEqSequence(SliceSized[int](s), Sequence[int](intSequence{0, benchmarkLen}), equal)
+	}
+}
@@ -6,12 +6,29 @@ import (
 )
 
 var (
-	ErrOddValues   = errors.New("odd number of values")
-	ErrNilAction   = errors.New("nil action")
-	ErrNilEqual    = errors.New("nil equal")
-	ErrNilSec      = errors.New("nil Sec")
-	ErrNilSec2     = errors.New("nil Sec2")
-	ErrNilSelector = errors.New("nil selector")
+	ErrOddValues    = errors.New("odd number of values")
+	ErrNilAction    = errors.New("nil action")
+	ErrNilEqual     = errors.New("nil equal")
+	ErrNilCompare   = errors.New("nil compare")
+	ErrNilSec       = errors.New("nil Sec")
+	ErrNilSec2      = errors.New("nil Sec2")
+	ErrNilSelector  = errors.New("nil selector")
+	ErrNilPredicate = errors.New("nil predicate")
+
+	ErrNegativeCount      = errors.New("negative count")
+	ErrNonPositiveCount   = errors.New("non-positive count")
+	ErrNonPositiveWorkers = errors.New("non-positive workers")
+	ErrUnknownPolicy      = errors.New("unknown policy")
+	ErrSlowConsumer       = errors.New("slow consumer")
+	ErrDuplicateKey       = errors.New("duplicate key")
+	ErrNilMerge           = errors.New("nil merge")
+	ErrNilParse           = errors.New("nil parse")
+	ErrMalformedString    = errors.New("malformed string")
+	ErrNilReader          = errors.New("nil reader")
+	ErrNilWriter          = errors.New("nil writer")
+	ErrNilSplit           = errors.New("nil split")
+	ErrNilFS              = errors.New("nil FS")
+	ErrNotJSONArray       = errors.New("not JSON array")
 )
 
 func ErrWrongType(got, want any) error {
@@ -0,0 +1,47 @@
+package iterhelper
+
+import (
+	"iter"
+
+	"github.com/solsw/errorhelper"
+)
+
+// Filter returns an [iterator] over values yielded by 'seq' that satisfy 'predicate'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func Filter[V any](seq iter.Seq[V], predicate func(V) bool) (iter.Seq[V], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if predicate == nil {
+		return nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	return func(yield func(V) bool) {
+			for v := range seq {
+				if predicate(v) && !yield(v) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// Filter2 returns an [iterator] over pairs of values yielded by 'seq2' that satisfy 'predicate'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func Filter2[K, V any](seq2 iter.Seq2[K, V], predicate func(K, V) bool) (iter.Seq2[K, V], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if predicate == nil {
+		return nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	return func(yield func(K, V) bool) {
+			for k, v := range seq2 {
+				if predicate(k, v) && !yield(k, v) {
+					return
+				}
+			}
+		},
+		nil
+}
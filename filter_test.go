@@ -0,0 +1,88 @@
+package iterhelper
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestFilter_int(t *testing.T) {
+	type args struct {
+		seq       iter.Seq[int]
+		predicate func(int) bool
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        iter.Seq[int]
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSource",
+			args: args{
+				seq:       nil,
+				predicate: func(int) bool { return true },
+			},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "NilPredicate",
+			args: args{
+				seq:       Var(1, 2, 3),
+				predicate: nil,
+			},
+			wantErr:     true,
+			expectedErr: ErrNilPredicate,
+		},
+		{name: "NoneMatch",
+			args: args{
+				seq:       Var(1, 3, 5),
+				predicate: func(i int) bool { return i%2 == 0 },
+			},
+			want: Empty[int](),
+		},
+		{name: "Regular",
+			args: args{
+				seq:       intSeq(1, 10),
+				predicate: func(i int) bool { return i%3 == 0 },
+			},
+			want: Var(3, 6, 9),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Filter(tt.args.seq, tt.args.predicate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Filter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Filter() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			equal, _ := Equal(got, tt.want)
+			if !equal {
+				t.Errorf("Filter() = %v, want %v", StringDef(got), StringDef(tt.want))
+			}
+		})
+	}
+}
+
+func TestFilter2_int_string(t *testing.T) {
+	got, err := Filter2(sec2_int_string(5), func(i int, _ string) bool { return i%2 == 1 })
+	if err != nil {
+		t.Fatalf("Filter2() error = %v", err)
+	}
+	want := Var2Tuple(
+		generichelper.NewTuple2(1, "1"),
+		generichelper.NewTuple2(3, "3"),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("Filter2() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+}
@@ -34,32 +34,42 @@ func ForEach[V any](ctx context.Context, seq iter.Seq[V], action func(V) error)
 }
 
 // ForEachConcurrent concurrently performs a specified 'action' on each value yielded by the [iterator].
-// If 'ctx' is canceled or 'action' returns a non-nil error,
-// the operation is stopped and corresponding error is returned.
+// 'action' receives a context derived from 'ctx', which is canceled
+// as soon as 'ctx' is canceled or any 'action' returns a non-nil error.
+// After that no further values are pulled from the [iterator]
+// and corresponding error is returned.
 //
 // [iterator]: https://pkg.go.dev/iter#Seq
-func ForEachConcurrent[V any](ctx context.Context, seq iter.Seq[V], action func(V) error) error {
+func ForEachConcurrent[V any](ctx context.Context, seq iter.Seq[V], action func(context.Context, V) error) error {
 	if seq == nil {
 		return errorhelper.CallerError(ErrNilSec)
 	}
 	if action == nil {
 		return errorhelper.CallerError(ErrNilAction)
 	}
-	g := new(errgroup.Group)
-	for v := range seq {
-		g.Go(func() error {
-			select {
-			case <-ctx.Done():
-				return errorhelper.CallerError(ctx.Err())
-			default:
-				if err := action(v); err != nil {
-					return errorhelper.CallerError(err)
-				}
-			}
-			return nil
-		})
+	return errorhelper.CallerError(forEachConcurrent(ctx, seq, -1, action))
+}
+
+// ForEachConcurrentN concurrently performs a specified 'action' on each value yielded by the [iterator]
+// using at most 'workers' goroutines at a time.
+// While all workers are busy, no further values are pulled from the [iterator].
+// 'action' receives a context derived from 'ctx', which is canceled
+// as soon as 'ctx' is canceled or any 'action' returns a non-nil error.
+// After that no further values are pulled from the [iterator]
+// and corresponding error is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func ForEachConcurrentN[V any](ctx context.Context, seq iter.Seq[V], workers int, action func(context.Context, V) error) error {
+	if seq == nil {
+		return errorhelper.CallerError(ErrNilSec)
 	}
-	return errorhelper.CallerError(g.Wait())
+	if workers <= 0 {
+		return errorhelper.CallerError(ErrNonPositiveWorkers)
+	}
+	if action == nil {
+		return errorhelper.CallerError(ErrNilAction)
+	}
+	return errorhelper.CallerError(forEachConcurrent(ctx, seq, workers, action))
 }
 
 // ForEach2 sequentially performs a specified 'action' on each pair of values yielded by the [iterator].
@@ -88,33 +98,96 @@ func ForEach2[K, V any](ctx context.Context, seq2 iter.Seq2[K, V], action func(K
 }
 
 // ForEachConcurrent2 concurrently performs a specified 'action' on each pair of values yielded by the [iterator].
-// If 'ctx' is canceled or 'action' returns a non-nil error,
-// the operation is stopped and corresponding error is returned.
+// 'action' receives a context derived from 'ctx', which is canceled
+// as soon as 'ctx' is canceled or any 'action' returns a non-nil error.
+// After that no further pairs are pulled from the [iterator]
+// and corresponding error is returned.
 //
 // [iterator]: https://pkg.go.dev/iter#Seq2
-func ForEachConcurrent2[K, V any](ctx context.Context, seq2 iter.Seq2[K, V], action func(K, V) error) error {
+func ForEachConcurrent2[K, V any](ctx context.Context, seq2 iter.Seq2[K, V], action func(context.Context, K, V) error) error {
 	if seq2 == nil {
-		return errorhelper.CallerError(ErrNilSec)
+		return errorhelper.CallerError(ErrNilSec2)
 	}
 	if action == nil {
 		return errorhelper.CallerError(ErrNilAction)
 	}
-	g := new(errgroup.Group)
+	return errorhelper.CallerError(forEachConcurrent2(ctx, seq2, -1, action))
+}
+
+// ForEachConcurrentN2 concurrently performs a specified 'action' on each pair of values yielded by the [iterator]
+// using at most 'workers' goroutines at a time.
+// While all workers are busy, no further pairs are pulled from the [iterator].
+// 'action' receives a context derived from 'ctx', which is canceled
+// as soon as 'ctx' is canceled or any 'action' returns a non-nil error.
+// After that no further pairs are pulled from the [iterator]
+// and corresponding error is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func ForEachConcurrentN2[K, V any](ctx context.Context, seq2 iter.Seq2[K, V], workers int, action func(context.Context, K, V) error) error {
+	if seq2 == nil {
+		return errorhelper.CallerError(ErrNilSec2)
+	}
+	if workers <= 0 {
+		return errorhelper.CallerError(ErrNonPositiveWorkers)
+	}
+	if action == nil {
+		return errorhelper.CallerError(ErrNilAction)
+	}
+	return errorhelper.CallerError(forEachConcurrent2(ctx, seq2, workers, action))
+}
+
+// forEachConcurrent is the common implementation of [ForEachConcurrent] and [ForEachConcurrentN].
+// Negative 'limit' means no limit on the number of active goroutines.
+func forEachConcurrent[V any](ctx context.Context, seq iter.Seq[V], limit int, action func(context.Context, V) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	stopped := false
+	for v := range seq {
+		if gctx.Err() != nil {
+			stopped = true
+			break
+		}
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			return action(gctx, v)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if stopped {
+		// no 'action' failed, so 'ctx' itself is done
+		return ctx.Err()
+	}
+	return nil
+}
+
+// forEachConcurrent2 is the common implementation of [ForEachConcurrent2] and [ForEachConcurrentN2].
+// Negative 'limit' means no limit on the number of active goroutines.
+func forEachConcurrent2[K, V any](ctx context.Context, seq2 iter.Seq2[K, V], limit int, action func(context.Context, K, V) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	stopped := false
 	for k, v := range seq2 {
+		if gctx.Err() != nil {
+			stopped = true
+			break
+		}
 		g.Go(func() error {
-			select {
-			case <-ctx.Done():
-				return errorhelper.CallerError(ctx.Err())
-			default:
-				if err := action(k, v); err != nil {
-					return errorhelper.CallerError(err)
-				}
+			if err := gctx.Err(); err != nil {
+				return err
 			}
-			return nil
+			return action(gctx, k, v)
 		})
 	}
 	if err := g.Wait(); err != nil {
-		return errorhelper.CallerError(err)
+		return err
+	}
+	if stopped {
+		// no 'action' failed, so 'ctx' itself is done
+		return ctx.Err()
 	}
 	return nil
 }
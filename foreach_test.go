@@ -5,14 +5,50 @@ import (
 	"errors"
 	"iter"
 	"reflect"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/solsw/errorhelper"
 )
 
 var ErrTestError = errors.New("test error")
 
+// workerProbe records the maximum number of concurrently running actions.
+// Actions wait until 'workers' of them are running at once,
+// so that an excess goroutine, if any, has a chance to start meanwhile.
+type workerProbe struct {
+	workers    int64
+	running    atomic.Int64
+	maxRunning atomic.Int64
+	full       chan struct{}
+	fullOnce   sync.Once
+}
+
+func newWorkerProbe(workers int) *workerProbe {
+	return &workerProbe{workers: int64(workers), full: make(chan struct{})}
+}
+
+func (wp *workerProbe) run() {
+	r := wp.running.Add(1)
+	defer wp.running.Add(-1)
+	for {
+		m := wp.maxRunning.Load()
+		if r <= m || wp.maxRunning.CompareAndSwap(m, r) {
+			break
+		}
+	}
+	if r >= wp.workers {
+		wp.fullOnce.Do(func() { close(wp.full) })
+	}
+	select {
+	case <-wp.full:
+	case <-time.After(time.Second):
+	}
+	time.Sleep(50 * time.Microsecond)
+}
+
 func TestForEach_int(t *testing.T) {
 	var acc1 int
 	ctx1, cancel := context.WithCancel(context.Background())
@@ -116,7 +152,7 @@ func TestForEachConcurrent_int(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		seq    iter.Seq[int]
-		action func(int) error
+		action func(context.Context, int) error
 	}
 	tests := []struct {
 		name        string
@@ -129,7 +165,7 @@ func TestForEachConcurrent_int(t *testing.T) {
 			args: args{
 				ctx:    canceledCtx,
 				seq:    Var(1, 2, 3),
-				action: func(int) error { return nil },
+				action: func(context.Context, int) error { return nil },
 			},
 			wantErr:     true,
 			expectedErr: context.Canceled,
@@ -138,7 +174,7 @@ func TestForEachConcurrent_int(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				seq: Var(1, 2, 3),
-				action: func(i int) error {
+				action: func(_ context.Context, i int) error {
 					if i == 2 {
 						return errorhelper.CallerError(ErrTestError)
 					}
@@ -153,7 +189,7 @@ func TestForEachConcurrent_int(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				seq: intSeq(1, 1000),
-				action: func(i int) error {
+				action: func(_ context.Context, i int) error {
 					// acc1 += int64(i * i) // <- demonstrates race error
 					atomic.AddInt64(&acc1, int64(i*i))
 					return nil
@@ -182,3 +218,278 @@ func TestForEachConcurrent_int(t *testing.T) {
 		})
 	}
 }
+
+func TestForEachConcurrentN_int(t *testing.T) {
+	var acc1 int64
+	var probe *workerProbe
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	type args struct {
+		ctx     context.Context
+		seq     iter.Seq[int]
+		workers int
+		action  func(context.Context, int) error
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        int64
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "01",
+			args: args{
+				ctx:     context.Background(),
+				seq:     Var(1, 2, 3),
+				workers: 0,
+				action:  func(context.Context, int) error { return nil },
+			},
+			wantErr:     true,
+			expectedErr: ErrNonPositiveWorkers,
+		},
+		{name: "02",
+			args: args{
+				ctx:     canceledCtx,
+				seq:     Var(1, 2, 3),
+				workers: 2,
+				action:  func(context.Context, int) error { return nil },
+			},
+			wantErr:     true,
+			expectedErr: context.Canceled,
+		},
+		{name: "03",
+			args: args{
+				ctx:     context.Background(),
+				seq:     Var(1, 2, 3),
+				workers: 2,
+				action: func(_ context.Context, i int) error {
+					if i == 2 {
+						return errorhelper.CallerError(ErrTestError)
+					}
+					return nil
+				},
+			},
+			wantErr:     true,
+			expectedErr: ErrTestError,
+		},
+		{name: "1",
+			args: args{
+				ctx:     context.Background(),
+				seq:     intSeq(1, 1000),
+				workers: 4,
+				action: func(_ context.Context, i int) error {
+					probe.run()
+					atomic.AddInt64(&acc1, int64(i*i))
+					return nil
+				},
+			},
+			want: 333833500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc1 = 0
+			probe = newWorkerProbe(tt.args.workers)
+			err := ForEachConcurrentN(tt.args.ctx, tt.args.seq, tt.args.workers, tt.args.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ForEachConcurrentN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("ForEachConcurrentN() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(acc1, tt.want) {
+				t.Errorf("ForEachConcurrentN() = %v, want %v", acc1, tt.want)
+			}
+			if m := probe.maxRunning.Load(); m != int64(tt.args.workers) {
+				t.Errorf("ForEachConcurrentN() ran %v workers at most, want %v", m, tt.args.workers)
+			}
+		})
+	}
+}
+
+func TestForEachConcurrentN2_int(t *testing.T) {
+	var acc1 int64
+	var probe *workerProbe
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	type args struct {
+		ctx     context.Context
+		seq2    iter.Seq2[int, int]
+		workers int
+		action  func(context.Context, int, int) error
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        int64
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "00",
+			args: args{
+				ctx:     context.Background(),
+				seq2:    nil,
+				workers: 2,
+				action:  func(context.Context, int, int) error { return nil },
+			},
+			wantErr:     true,
+			expectedErr: ErrNilSec2,
+		},
+		{name: "01",
+			args: args{
+				ctx:     context.Background(),
+				seq2:    errorhelper.Must(Enumerate(Var(1, 2, 3))),
+				workers: 0,
+				action:  func(context.Context, int, int) error { return nil },
+			},
+			wantErr:     true,
+			expectedErr: ErrNonPositiveWorkers,
+		},
+		{name: "02",
+			args: args{
+				ctx:     canceledCtx,
+				seq2:    errorhelper.Must(Enumerate(Var(1, 2, 3))),
+				workers: 2,
+				action:  func(context.Context, int, int) error { return nil },
+			},
+			wantErr:     true,
+			expectedErr: context.Canceled,
+		},
+		{name: "03",
+			args: args{
+				ctx:     context.Background(),
+				seq2:    errorhelper.Must(Enumerate(Var(1, 2, 3))),
+				workers: 2,
+				action: func(_ context.Context, _, i int) error {
+					if i == 2 {
+						return errorhelper.CallerError(ErrTestError)
+					}
+					return nil
+				},
+			},
+			wantErr:     true,
+			expectedErr: ErrTestError,
+		},
+		{name: "1",
+			args: args{
+				ctx:     context.Background(),
+				seq2:    errorhelper.Must(Enumerate(intSeq(1, 1000))),
+				workers: 4,
+				action: func(_ context.Context, _, i int) error {
+					probe.run()
+					atomic.AddInt64(&acc1, int64(i*i))
+					return nil
+				},
+			},
+			want: 333833500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc1 = 0
+			probe = newWorkerProbe(tt.args.workers)
+			err := ForEachConcurrentN2(tt.args.ctx, tt.args.seq2, tt.args.workers, tt.args.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ForEachConcurrentN2() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("ForEachConcurrentN2() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(acc1, tt.want) {
+				t.Errorf("ForEachConcurrentN2() = %v, want %v", acc1, tt.want)
+			}
+			if m := probe.maxRunning.Load(); m != int64(tt.args.workers) {
+				t.Errorf("ForEachConcurrentN2() ran %v workers at most, want %v", m, tt.args.workers)
+			}
+		})
+	}
+}
+
+func TestForEachConcurrentN_stopsPulling(t *testing.T) {
+	var pulled int64
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			atomic.AddInt64(&pulled, 1)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	err := ForEachConcurrentN(context.Background(), seq, 2,
+		func(ctx context.Context, i int) error {
+			if i == 0 {
+				return ErrTestError
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	if !errors.Is(err, ErrTestError) {
+		t.Errorf("ForEachConcurrentN() error = %v, expectedErr %v", err, ErrTestError)
+	}
+	// at most: 2 values for busy workers, 1 value waiting for a free worker, 1 value yielded after cancellation
+	if pulled > 4 {
+		t.Errorf("ForEachConcurrentN() pulled %v values after failure", pulled)
+	}
+}
+
+func TestForEachConcurrentN2_stopsPulling(t *testing.T) {
+	var pulled int64
+	seq2 := func(yield func(int, int) bool) {
+		for i := 0; ; i++ {
+			atomic.AddInt64(&pulled, 1)
+			if !yield(i, i*i) {
+				return
+			}
+		}
+	}
+	err := ForEachConcurrentN2(context.Background(), seq2, 2,
+		func(ctx context.Context, i, _ int) error {
+			if i == 0 {
+				return ErrTestError
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	if !errors.Is(err, ErrTestError) {
+		t.Errorf("ForEachConcurrentN2() error = %v, expectedErr %v", err, ErrTestError)
+	}
+	// at most: 2 pairs for busy workers, 1 pair waiting for a free worker, 1 pair yielded after cancellation
+	if pulled > 4 {
+		t.Errorf("ForEachConcurrentN2() pulled %v pairs after failure", pulled)
+	}
+}
+
+func TestForEachConcurrent2_derivedContext(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	var canceled int64
+	err := ForEachConcurrent2(ctx, sec2_int_string(10), func(actx context.Context, i int, _ string) error {
+		if actx.Value(ctxKey{}) != "value" {
+			t.Errorf("ForEachConcurrent2() action context is not derived from ctx")
+		}
+		if i == 0 {
+			return ErrTestError
+		}
+		// every other action observes cancellation caused by the failed one
+		<-actx.Done()
+		atomic.AddInt64(&canceled, 1)
+		return nil
+	})
+	if !errors.Is(err, ErrTestError) {
+		t.Errorf("ForEachConcurrent2() error = %v, expectedErr %v", err, ErrTestError)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("ForEachConcurrent2() canceled the parent context")
+	}
+	if err := ForEachConcurrent2[int, string](ctx, nil, nil); !errors.Is(err, ErrNilSec2) {
+		t.Errorf("ForEachConcurrent2() error = %v, expectedErr %v", err, ErrNilSec2)
+	}
+}
@@ -0,0 +1,201 @@
+package iterhelper
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// WriteJSONArray writes values yielded by the [iterator] to 'w' as a JSON array.
+// Each value is encoded by [json.Marshal] and written as soon as it is yielded,
+// so the whole sequence is never held in memory.
+// The number of bytes written and the first write or encoding error, if any, are returned.
+// No further values are pulled from the [iterator] after an error, so the written JSON is incomplete then.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func WriteJSONArray[V any](w io.Writer, seq iter.Seq[V]) (int64, error) {
+	if w == nil {
+		return 0, errorhelper.CallerError(ErrNilWriter)
+	}
+	if seq == nil {
+		return 0, errorhelper.CallerError(ErrNilSec)
+	}
+	n, err := writeJSON(w, seq, "[", ",", "]", func(v V) ([]byte, error) {
+		return json.Marshal(v)
+	})
+	return n, errorhelper.CallerError(err)
+}
+
+// WriteJSONLines writes values yielded by the [iterator] to 'w' in [JSON Lines] format,
+// i.e. each value encoded by [json.Marshal] followed by a newline.
+// Otherwise WriteJSONLines behaves like [WriteJSONArray].
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+// [JSON Lines]: https://jsonlines.org/
+func WriteJSONLines[V any](w io.Writer, seq iter.Seq[V]) (int64, error) {
+	if w == nil {
+		return 0, errorhelper.CallerError(ErrNilWriter)
+	}
+	if seq == nil {
+		return 0, errorhelper.CallerError(ErrNilSec)
+	}
+	n, err := writeJSON(w, seq, "", "", "", func(v V) ([]byte, error) {
+		b, err := json.Marshal(v)
+		return append(b, '\n'), err
+	})
+	return n, errorhelper.CallerError(err)
+}
+
+// WriteJSONObject writes pairs of values yielded by the [iterator] to 'w' as members of a JSON object.
+// A key is converted to a string by its MarshalText method, if it implements [encoding.TextMarshaler],
+// or by [fmt.Sprint] otherwise. A value is encoded by [json.Marshal].
+// Keys are written in the order they are yielded, duplicate keys are not detected.
+// Otherwise WriteJSONObject behaves like [WriteJSONArray].
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func WriteJSONObject[K, V any](w io.Writer, seq2 iter.Seq2[K, V]) (int64, error) {
+	if w == nil {
+		return 0, errorhelper.CallerError(ErrNilWriter)
+	}
+	if seq2 == nil {
+		return 0, errorhelper.CallerError(ErrNilSec2)
+	}
+	n, err := writeJSON(w, tupleSeq(seq2), "{", ",", "}", func(t generichelper.Tuple2[K, V]) ([]byte, error) {
+		k, err := jsonKey(t.Item1)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(t.Item2)
+		if err != nil {
+			return nil, err
+		}
+		return append(append(b, ':'), v...), nil
+	})
+	return n, errorhelper.CallerError(err)
+}
+
+// jsonKey returns string representation of 'k' used as a JSON object key.
+func jsonKey(k any) (string, error) {
+	switch k := k.(type) {
+	case string:
+		return k, nil
+	case encoding.TextMarshaler:
+		b, err := k.MarshalText()
+		return string(b), err
+	default:
+		return fmt.Sprint(k), nil
+	}
+}
+
+// writeJSON writes elements yielded by 'seq' and encoded by 'marshal' to 'w'
+// between 'open' and 'close' separated by 'sep'.
+func writeJSON[E any](w io.Writer, seq iter.Seq[E], open, sep, close string, marshal func(E) ([]byte, error)) (int64, error) {
+	fw := fmtWriter{w: w}
+	fw.str(open)
+	first := true
+	for e := range seq {
+		b, err := marshal(e)
+		if err != nil {
+			return fw.n, err
+		}
+		if !first {
+			fw.str(sep)
+		}
+		fw.write(b)
+		if fw.err != nil {
+			return fw.n, fw.err
+		}
+		first = false
+	}
+	fw.str(close)
+	return fw.n, fw.err
+}
+
+// DecodeJSONLines returns a [SeqErr] of values decoded by [json.Decoder] one by one
+// from 'r' containing whitespace separated JSON values, e.g. in [JSON Lines] format.
+// A value that does not match 'V' is yielded as the zero value along with [json.UnmarshalTypeError]
+// and the iteration proceeds. Any other error is yielded along with the zero value and ends the iteration.
+// Values are read from 'r' as they are pulled, so the returned [SeqErr] can be iterated only once.
+//
+// [JSON Lines]: https://jsonlines.org/
+func DecodeJSONLines[V any](r io.Reader) (SeqErr[V], error) {
+	if r == nil {
+		return nil, errorhelper.CallerError(ErrNilReader)
+	}
+	return func(yield func(V, error) bool) {
+			dec := json.NewDecoder(r)
+			for {
+				v, stop, err := decodeJSON[V](dec)
+				if err == io.EOF {
+					return
+				}
+				if !yield(v, errorhelper.CallerError(err)) || stop {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// DecodeJSONArray returns a [SeqErr] of elements of the JSON array read from 'r'.
+// Elements are decoded by [json.Decoder] one by one as they are pulled,
+// so the whole array is never held in memory.
+// If 'r' does not start with a JSON array, [ErrNotJSONArray] is yielded and the iteration ends.
+// Otherwise DecodeJSONArray behaves like [DecodeJSONLines].
+func DecodeJSONArray[V any](r io.Reader) (SeqErr[V], error) {
+	if r == nil {
+		return nil, errorhelper.CallerError(ErrNilReader)
+	}
+	return func(yield func(V, error) bool) {
+			zero := generichelper.ZeroValue[V]()
+			dec := json.NewDecoder(r)
+			tok, err := dec.Token()
+			if err != nil {
+				yield(zero, errorhelper.CallerError(unexpectedEOF(err)))
+				return
+			}
+			if d, ok := tok.(json.Delim); !ok || d != '[' {
+				yield(zero, errorhelper.CallerError(fmt.Errorf("%w: got %v", ErrNotJSONArray, tok)))
+				return
+			}
+			for dec.More() {
+				v, stop, err := decodeJSON[V](dec)
+				if !yield(v, errorhelper.CallerError(unexpectedEOF(err))) || stop {
+					return
+				}
+			}
+			// the closing bracket
+			if _, err := dec.Token(); err != nil {
+				yield(zero, errorhelper.CallerError(unexpectedEOF(err)))
+			}
+		},
+		nil
+}
+
+// decodeJSON decodes the next value from 'dec'.
+// 'stop' is true if the decoding cannot be continued.
+func decodeJSON[V any](dec *json.Decoder) (v V, stop bool, err error) {
+	if err = dec.Decode(&v); err == nil {
+		return v, false, nil
+	}
+	var typeErr *json.UnmarshalTypeError
+	return generichelper.ZeroValue[V](), !errors.As(err, &typeErr), err
+}
+
+// unexpectedEOF replaces [io.EOF] with [io.ErrUnexpectedEOF].
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
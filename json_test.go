@@ -0,0 +1,403 @@
+package iterhelper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+type jsonPoint struct {
+	X, Y int
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p jsonPoint) MarshalText() ([]byte, error) {
+	if p.X < 0 {
+		return nil, ErrTestError
+	}
+	return []byte(strings.Repeat("x", p.X) + strings.Repeat("y", p.Y)), nil
+}
+
+func TestWriteJSONArray(t *testing.T) {
+	tests := []struct {
+		name        string
+		seq         iter.Seq[any]
+		want        string
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSource",
+			seq:         nil,
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "Empty",
+			seq:  Empty[any](),
+			want: "[]",
+		},
+		{name: "Regular",
+			seq:  Var[any](1, "two", []int{3}, nil, jsonPoint{X: 4}),
+			want: `[1,"two",[3],null,"xxxx"]`,
+		},
+		{name: "MarshalError",
+			seq:     Var[any](1, make(chan int), 3),
+			want:    "[1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			n, err := WriteJSONArray(&b, tt.seq)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WriteJSONArray() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("WriteJSONArray() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if got := b.String(); got != tt.want || n != int64(len(got)) {
+				t.Errorf("WriteJSONArray() = %q, %v, want %q", got, n, tt.want)
+			}
+			if !tt.wantErr && !json.Valid([]byte(b.String())) {
+				t.Errorf("WriteJSONArray() = %q is not valid JSON", b.String())
+			}
+		})
+	}
+}
+
+func TestWriteJSONArray_writeError(t *testing.T) {
+	pulled := 0
+	seq := func(yield func(int) bool) {
+		for i := range 100 {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	w := &failingWriter{limit: 5}
+	n, err := WriteJSONArray(w, seq)
+	if !errors.Is(err, ErrTestError) {
+		t.Errorf("WriteJSONArray() error = %v, expectedErr %v", err, ErrTestError)
+	}
+	if n != 5 || w.b.String() != "[0,1," {
+		t.Errorf("WriteJSONArray() = %q, %v, want %q, %v", w.b.String(), n, "[0,1,", 5)
+	}
+	if pulled != 3 {
+		t.Errorf("WriteJSONArray() pulled %v values, want %v", pulled, 3)
+	}
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	if _, err := WriteJSONLines[int](&strings.Builder{}, nil); !errors.Is(err, ErrNilSec) {
+		t.Errorf("WriteJSONLines() error = %v, expectedErr %v", err, ErrNilSec)
+	}
+	var b strings.Builder
+	n, err := WriteJSONLines(&b, Var(jsonPoint{1, 2}, jsonPoint{3, 4}))
+	if err != nil {
+		t.Fatalf("WriteJSONLines() error = %v", err)
+	}
+	want := "\"xyy\"\n\"xxxyyyy\"\n"
+	if got := b.String(); got != want || n != int64(len(want)) {
+		t.Errorf("WriteJSONLines() = %q, %v, want %q", got, n, want)
+	}
+	b.Reset()
+	if n, err := WriteJSONLines(&b, Empty[int]()); err != nil || n != 0 || b.Len() != 0 {
+		t.Errorf("WriteJSONLines() = %q, %v, %v, want empty", b.String(), n, err)
+	}
+}
+
+func TestWriteJSONObject(t *testing.T) {
+	if _, err := WriteJSONObject[int, int](&strings.Builder{}, nil); !errors.Is(err, ErrNilSec2) {
+		t.Errorf("WriteJSONObject() error = %v, expectedErr %v", err, ErrNilSec2)
+	}
+	tests := []struct {
+		name        string
+		write       func(*strings.Builder) (int64, error)
+		want        string
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "Empty",
+			write: func(b *strings.Builder) (int64, error) { return WriteJSONObject(b, Empty2[string, int]()) },
+			want:  "{}",
+		},
+		{name: "FmtKeys",
+			write: func(b *strings.Builder) (int64, error) { return WriteJSONObject(b, sec2_int_string(3)) },
+			want:  `{"0":"0","1":"1","2":"2"}`,
+		},
+		{name: "StringKeys",
+			write: func(b *strings.Builder) (int64, error) {
+				return WriteJSONObject(b, Var2Tuple(generichelper.NewTuple2[string, any]("a\"b", []int{1}), generichelper.NewTuple2[string, any]("c", nil)))
+			},
+			want: `{"a\"b":[1],"c":null}`,
+		},
+		{name: "TextMarshalerKeys",
+			write: func(b *strings.Builder) (int64, error) {
+				return WriteJSONObject(b, Var2Tuple(generichelper.NewTuple2(jsonPoint{1, 1}, true), generichelper.NewTuple2(jsonPoint{2, 0}, false)))
+			},
+			want: `{"xy":true,"xx":false}`,
+		},
+		{name: "KeyError",
+			write: func(b *strings.Builder) (int64, error) {
+				return WriteJSONObject(b, Var2Tuple(generichelper.NewTuple2(jsonPoint{1, 0}, 1), generichelper.NewTuple2(jsonPoint{-1, 0}, 2)))
+			},
+			want:        `{"x":1`,
+			wantErr:     true,
+			expectedErr: ErrTestError,
+		},
+		{name: "ValueError",
+			write: func(b *strings.Builder) (int64, error) {
+				return WriteJSONObject(b, Var2Tuple(generichelper.NewTuple2[string, any]("a", 1), generichelper.NewTuple2[string, any]("b", func() {})))
+			},
+			want:    `{"a":1`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			n, err := tt.write(&b)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WriteJSONObject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("WriteJSONObject() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if got := b.String(); got != tt.want || n != int64(len(got)) {
+				t.Errorf("WriteJSONObject() = %q, %v, want %q", got, n, tt.want)
+			}
+			if !tt.wantErr && !json.Valid([]byte(b.String())) {
+				t.Errorf("WriteJSONObject() = %q is not valid JSON", b.String())
+			}
+		})
+	}
+}
+
+// errReader always fails with ErrTestError.
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, ErrTestError
+}
+
+func collectErr[V any](seqErr SeqErr[V]) ([]V, []error) {
+	var vv []V
+	var ee []error
+	for v, err := range seqErr {
+		vv = append(vv, v)
+		ee = append(ee, err)
+	}
+	return vv, ee
+}
+
+func TestDecodeJSONLines_int(t *testing.T) {
+	if _, err := DecodeJSONLines[int](nil); !errors.Is(err, ErrNilReader) {
+		t.Errorf("DecodeJSONLines() error = %v, expectedErr %v", err, ErrNilReader)
+	}
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    []int
+		wantErr []error
+	}{
+		{name: "Empty",
+			r:       strings.NewReader(" \n"),
+			want:    nil,
+			wantErr: nil,
+		},
+		{name: "Regular",
+			r:       strings.NewReader("1\n2\n\n3"),
+			want:    []int{1, 2, 3},
+			wantErr: []error{nil, nil, nil},
+		},
+		{name: "TypeError",
+			r:       strings.NewReader("1\n\"two\"\n3\n"),
+			want:    []int{1, 0, 3},
+			wantErr: []error{nil, &json.UnmarshalTypeError{}, nil},
+		},
+		{name: "SyntaxError",
+			r:       strings.NewReader("1\n}\n3\n"),
+			want:    []int{1, 0},
+			wantErr: []error{nil, &json.SyntaxError{}},
+		},
+		{name: "Truncated",
+			r:       strings.NewReader("1\n[2"),
+			want:    []int{1, 0},
+			wantErr: []error{nil, io.ErrUnexpectedEOF},
+		},
+		{name: "ReadError",
+			r:       io.MultiReader(strings.NewReader("1 "), errReader{}),
+			want:    []int{1, 0},
+			wantErr: []error{nil, ErrTestError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqErr, err := DecodeJSONLines[int](tt.r)
+			if err != nil {
+				t.Fatalf("DecodeJSONLines() error = %v", err)
+			}
+			got, errs := collectErr(seqErr)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DecodeJSONLines() = %v, want %v", got, tt.want)
+			}
+			checkErrs(t, "DecodeJSONLines", errs, tt.wantErr)
+		})
+	}
+}
+
+// checkErrs checks that each of 'got' matches the corresponding 'want' by value or by type.
+func checkErrs(t *testing.T, name string, got, want []error) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s() errors = %v, want %v", name, got, want)
+		return
+	}
+	for i, w := range want {
+		switch w := w.(type) {
+		case nil:
+			if got[i] != nil {
+				t.Errorf("%s() error[%d] = %v, want nil", name, i, got[i])
+			}
+		case *json.UnmarshalTypeError:
+			if !errors.As(got[i], &w) {
+				t.Errorf("%s() error[%d] = %v, want %T", name, i, got[i], w)
+			}
+		case *json.SyntaxError:
+			if !errors.As(got[i], &w) {
+				t.Errorf("%s() error[%d] = %v, want %T", name, i, got[i], w)
+			}
+		default:
+			if !errors.Is(got[i], w) {
+				t.Errorf("%s() error[%d] = %v, want %v", name, i, got[i], w)
+			}
+		}
+	}
+}
+
+func TestDecodeJSONArray_point(t *testing.T) {
+	if _, err := DecodeJSONArray[int](nil); !errors.Is(err, ErrNilReader) {
+		t.Errorf("DecodeJSONArray() error = %v, expectedErr %v", err, ErrNilReader)
+	}
+	type point struct {
+		X, Y int
+	}
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    []point
+		wantErr []error
+	}{
+		{name: "EmptyInput",
+			r:       strings.NewReader(""),
+			want:    []point{{}},
+			wantErr: []error{io.ErrUnexpectedEOF},
+		},
+		{name: "NotArray",
+			r:       strings.NewReader(`{"X":1}`),
+			want:    []point{{}},
+			wantErr: []error{ErrNotJSONArray},
+		},
+		{name: "EmptyArray",
+			r:       strings.NewReader(" [ ] "),
+			want:    nil,
+			wantErr: nil,
+		},
+		{name: "Regular",
+			r:       strings.NewReader(`[{"X":1,"Y":2}, {"Y":4}]`),
+			want:    []point{{1, 2}, {0, 4}},
+			wantErr: []error{nil, nil},
+		},
+		{name: "TypeError",
+			r:       strings.NewReader(`[{"X":1}, {"X":"2"}, {"X":3}]`),
+			want:    []point{{1, 0}, {}, {3, 0}},
+			wantErr: []error{nil, &json.UnmarshalTypeError{}, nil},
+		},
+		{name: "MissingComma",
+			r:       strings.NewReader(`[{"X":1} {"X":2}]`),
+			want:    []point{{1, 0}, {}},
+			wantErr: []error{nil, &json.SyntaxError{}},
+		},
+		{name: "Unterminated",
+			r:       strings.NewReader(`[{"X":1}`),
+			want:    []point{{1, 0}, {}},
+			wantErr: []error{nil, &json.SyntaxError{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqErr, err := DecodeJSONArray[point](tt.r)
+			if err != nil {
+				t.Fatalf("DecodeJSONArray() error = %v", err)
+			}
+			got, errs := collectErr(seqErr)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DecodeJSONArray() = %v, want %v", got, tt.want)
+			}
+			checkErrs(t, "DecodeJSONArray", errs, tt.wantErr)
+		})
+	}
+}
+
+func TestDecodeJSONArray_break(t *testing.T) {
+	// the failing rest of input must not be read before it is needed
+	seqErr, _ := DecodeJSONArray[int](io.MultiReader(strings.NewReader("[1,2,"), errReader{}))
+	var got []int
+	for v, err := range seqErr {
+		if err != nil {
+			t.Fatalf("DecodeJSONArray() error = %v", err)
+		}
+		got = append(got, v)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if want := []int{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("DecodeJSONArray() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeJSONArray_roundTrip(t *testing.T) {
+	var b strings.Builder
+	if _, err := WriteJSONArray(&b, intSeq(0, 1000)); err != nil {
+		t.Fatalf("WriteJSONArray() error = %v", err)
+	}
+	seqErr, _ := DecodeJSONArray[int](strings.NewReader(b.String()))
+	sum := 0
+	if err := ForEachErr(context.Background(), seqErr, func(i int) error { sum += i; return nil }); err != nil {
+		t.Fatalf("ForEachErr() error = %v", err)
+	}
+	if want := 999 * 1000 / 2; sum != want {
+		t.Errorf("DecodeJSONArray() sum = %v, want %v", sum, want)
+	}
+}
+
+func TestWriters_nilWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func() (int64, error)
+	}{
+		{name: "WriteFmt", write: func() (int64, error) { return WriteFmt(nil, Var(1), DefaultFormat) }},
+		{name: "WriteFmt2", write: func() (int64, error) { return WriteFmt2(nil, sec2_int_string(1), DefaultFormat) }},
+		{name: "WriteJSONArray", write: func() (int64, error) { return WriteJSONArray(nil, Var(1)) }},
+		{name: "WriteJSONLines", write: func() (int64, error) { return WriteJSONLines(nil, Var(1)) }},
+		{name: "WriteJSONObject", write: func() (int64, error) { return WriteJSONObject(nil, sec2_int_string(1)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if n, err := tt.write(); n != 0 || !errors.Is(err, ErrNilWriter) {
+				t.Errorf("%s() = %v, %v, expectedErr %v", tt.name, n, err, ErrNilWriter)
+			}
+		})
+	}
+}
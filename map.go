@@ -0,0 +1,88 @@
+package iterhelper
+
+import (
+	"iter"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// Map returns an [iterator] over values returned by 'selector'
+// for each value yielded by 'seq'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func Map[V, R any](seq iter.Seq[V], selector func(V) R) (iter.Seq[R], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if selector == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	return func(yield func(R) bool) {
+			for v := range seq {
+				if !yield(selector(v)) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// Map2 returns an [iterator] over pairs of values returned by 'selector'
+// for each pair of values yielded by 'seq2'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func Map2[K, V, K2, V2 any](seq2 iter.Seq2[K, V], selector func(K, V) (K2, V2)) (iter.Seq2[K2, V2], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if selector == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	return func(yield func(K2, V2) bool) {
+			for k, v := range seq2 {
+				if !yield(selector(k, v)) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// Enumerate returns an [iterator] over index-value pairs of values yielded by 'seq'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func Enumerate[V any](seq iter.Seq[V]) (iter.Seq2[int, V], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	return func(yield func(int, V) bool) {
+			i := 0
+			for v := range seq {
+				if !yield(i, v) {
+					return
+				}
+				i++
+			}
+		},
+		nil
+}
+
+// Enumerate2 returns an [iterator] over index-tuple pairs of pairs of values yielded by 'seq2'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func Enumerate2[K, V any](seq2 iter.Seq2[K, V]) (iter.Seq2[int, generichelper.Tuple2[K, V]], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	return func(yield func(int, generichelper.Tuple2[K, V]) bool) {
+			i := 0
+			for k, v := range seq2 {
+				if !yield(i, generichelper.NewTuple2(k, v)) {
+					return
+				}
+				i++
+			}
+		},
+		nil
+}
@@ -0,0 +1,127 @@
+package iterhelper
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestMap_int_string(t *testing.T) {
+	type args struct {
+		seq      iter.Seq[int]
+		selector func(int) string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        iter.Seq[string]
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSource",
+			args: args{
+				seq:      nil,
+				selector: strconv.Itoa,
+			},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "NilSelector",
+			args: args{
+				seq:      Var(1, 2, 3),
+				selector: nil,
+			},
+			wantErr:     true,
+			expectedErr: ErrNilSelector,
+		},
+		{name: "Empty",
+			args: args{
+				seq:      Empty[int](),
+				selector: strconv.Itoa,
+			},
+			want: Empty[string](),
+		},
+		{name: "Regular",
+			args: args{
+				seq:      Var(1, 2, 3),
+				selector: func(i int) string { return strconv.Itoa(i * 11) },
+			},
+			want: Var("11", "22", "33"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Map(tt.args.seq, tt.args.selector)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Map() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Map() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			equal, _ := Equal(got, tt.want)
+			if !equal {
+				t.Errorf("Map() = %v, want %v", StringDef(got), StringDef(tt.want))
+			}
+		})
+	}
+}
+
+func TestMap2_int_string(t *testing.T) {
+	got, err := Map2(sec2_int_string(3), func(i int, s string) (string, int) { return s + s, i * i })
+	if err != nil {
+		t.Fatalf("Map2() error = %v", err)
+	}
+	want := Var2Tuple(
+		generichelper.NewTuple2("00", 0),
+		generichelper.NewTuple2("11", 1),
+		generichelper.NewTuple2("22", 4),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("Map2() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+	if _, err := Map2[int, string, int, string](nil, nil); !errors.Is(err, ErrNilSec2) {
+		t.Errorf("Map2() error = %v, expectedErr %v", err, ErrNilSec2)
+	}
+}
+
+func TestEnumerate_string(t *testing.T) {
+	got, err := Enumerate(Var("a", "b", "c"))
+	if err != nil {
+		t.Fatalf("Enumerate() error = %v", err)
+	}
+	want := Var2Tuple(
+		generichelper.NewTuple2(0, "a"),
+		generichelper.NewTuple2(1, "b"),
+		generichelper.NewTuple2(2, "c"),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("Enumerate() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+}
+
+func TestEnumerate2_int_string(t *testing.T) {
+	got, err := Enumerate2(Var2Tuple(
+		generichelper.NewTuple2(10, "a"),
+		generichelper.NewTuple2(20, "b"),
+	))
+	if err != nil {
+		t.Fatalf("Enumerate2() error = %v", err)
+	}
+	want := Var2Tuple(
+		generichelper.NewTuple2(0, generichelper.NewTuple2(10, "a")),
+		generichelper.NewTuple2(1, generichelper.NewTuple2(20, "b")),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("Enumerate2() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+}
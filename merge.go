@@ -0,0 +1,97 @@
+package iterhelper
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/solsw/errorhelper"
+)
+
+// Merge returns an [iterator] over the elements of the [channel]s in the order of their arrival.
+// The sequence ends when all the [channel]s are closed or 'ctx' is done.
+// nil [channel]s are ignored.
+// If the consumer stops the iteration, all started goroutines are finished before returning.
+//
+// [channel]: https://go.dev/ref/spec#Channel_types
+// [iterator]: https://pkg.go.dev/iter#Seq
+func Merge[E any](ctx context.Context, chans ...<-chan E) iter.Seq[E] {
+	return merge(ctx, len(chans), true, func(cctx context.Context, i int) iter.Seq[E] {
+		return ChanAllCtx(cctx, chans[i])
+	})
+}
+
+// MergeSeqs returns an [iterator] over the values yielded by the [iterator]s 'seqs'.
+// Each of 'seqs' is ranged over in a separate goroutine,
+// values are yielded in the order of their arrival.
+// The sequence ends when all 'seqs' are exhausted or 'ctx' is done.
+// If the consumer stops the iteration or 'ctx' is done, the goroutines are not waited for,
+// since they may be blocked inside 'seqs'; each of them stops ranging over its source
+// without sending further values as soon as the source yields the next value or ends.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func MergeSeqs[V any](ctx context.Context, seqs ...iter.Seq[V]) (iter.Seq[V], error) {
+	for _, seq := range seqs {
+		if seq == nil {
+			return nil, errorhelper.CallerError(ErrNilSec)
+		}
+	}
+	return merge(ctx, len(seqs), false, func(_ context.Context, i int) iter.Seq[V] {
+			return seqs[i]
+		}),
+		nil
+}
+
+// merge is the common implementation of [Merge] and [MergeSeqs].
+// 'source' returns the i-th source sequence.
+// If 'wait' is true, the sources stop when 'cctx' is done,
+// so the forwarding goroutines are waited for when the iteration ends.
+func merge[V any](ctx context.Context, n int, wait bool, source func(cctx context.Context, i int) iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		cctx, cancel := context.WithCancel(ctx)
+		out := make(chan V)
+		defer func() {
+			cancel()
+			if wait {
+				// 'out' is closed after all the forwarding goroutines are finished
+				for range out {
+				}
+			}
+		}()
+		var wg sync.WaitGroup
+		wg.Add(n)
+		for i := range n {
+			go func() {
+				defer wg.Done()
+				for v := range source(cctx, i) {
+					if cctx.Err() != nil {
+						return
+					}
+					select {
+					case <-cctx.Done():
+						return
+					case out <- v:
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+		for {
+			// checked before the receive, since select picks randomly among ready cases
+			if cctx.Err() != nil {
+				return
+			}
+			select {
+			case <-cctx.Done():
+				return
+			case v, ok := <-out:
+				if !ok || !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package iterhelper
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMerge_int(t *testing.T) {
+	got := slices.Sorted(Merge(context.Background(), chn2(), nil, chn3(), closedCh()))
+	want := []int{1, 1, 2, 3, 4}
+	if !slices.Equal(got, want) {
+		t.Errorf("Merge() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(Merge[int](context.Background())); len(got) != 0 {
+		t.Errorf("Merge() = %v, want %v", got, []int{})
+	}
+}
+
+func TestMerge_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := slices.Collect(Merge(ctx, bufferedCh(100), bufferedCh(100))); len(got) != 0 {
+		t.Errorf("Merge() = %v, want %v", got, []int{})
+	}
+}
+
+func TestMerge_break(t *testing.T) {
+	// the channels are never closed
+	c1, c2 := make(chan int), make(chan int)
+	go func() { c1 <- 1 }()
+	for range Merge(context.Background(), c1, c2) {
+		break
+	}
+}
+
+func TestMergeSeqs_int(t *testing.T) {
+	got, err := MergeSeqs(context.Background(), intSeq(0, 3), Empty[int](), intSeq(10, 2))
+	if err != nil {
+		t.Fatalf("MergeSeqs() error = %v", err)
+	}
+	want := []int{0, 1, 2, 10, 11}
+	if rr := slices.Sorted(got); !slices.Equal(rr, want) {
+		t.Errorf("MergeSeqs() = %v, want %v", rr, want)
+	}
+	if _, err := MergeSeqs(context.Background(), Var(1), nil); !errors.Is(err, ErrNilSec) {
+		t.Errorf("MergeSeqs() error = %v, expectedErr %v", err, ErrNilSec)
+	}
+}
+
+func TestMergeSeqs_break(t *testing.T) {
+	var active int64
+	infinite := func(yield func(int) bool) {
+		atomic.AddInt64(&active, 1)
+		defer atomic.AddInt64(&active, -1)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	got, _ := MergeSeqs(context.Background(), infinite, infinite, infinite)
+	n := 0
+	for range got {
+		n++
+		if n == 100 {
+			break
+		}
+	}
+	// producers are not waited for, but they stop as soon as their sources yield
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt64(&active) != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if a := atomic.LoadInt64(&active); a != 0 {
+		t.Errorf("MergeSeqs() left %v active producers after break", a)
+	}
+}
+
+func TestMergeSeqs_blockedSource(t *testing.T) {
+	idle, release := idleSource(1)
+	defer release()
+	got, _ := MergeSeqs(context.Background(), idle, Var(2, 3))
+	finishes(t, "MergeSeqs", func() {
+		for range got {
+			break
+		}
+	})
+
+	idle, release2 := idleSource(1)
+	defer release2()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	got, _ = MergeSeqs(ctx, idle)
+	var vv []int
+	finishes(t, "MergeSeqs", func() {
+		for v := range got {
+			vv = append(vv, v)
+		}
+	})
+	if !slices.Equal(vv, []int{1}) {
+		t.Errorf("MergeSeqs() = %v, want %v", vv, []int{1})
+	}
+}
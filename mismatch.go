@@ -0,0 +1,199 @@
+package iterhelper
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// Mismatch describes the first difference between two sequences.
+type Mismatch[V any] struct {
+	// Index is the zero-based position of the first difference.
+	Index int
+	// First and Second are the elements of the sequences at Index.
+	// An element is the zero value if the corresponding sequence ended before Index.
+	First, Second V
+	// FirstEnded and SecondEnded report whether the corresponding sequence ended before Index.
+	// At most one of them is true.
+	FirstEnded, SecondEnded bool
+}
+
+// FirstMismatch returns the first difference between sequences yielded by two [iterator]s
+// by comparing their elements using [generichelper.DeepEqual].
+// If the sequences are equal, false is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func FirstMismatch[V any](first, second iter.Seq[V]) (Mismatch[V], bool, error) {
+	if first == nil || second == nil {
+		return Mismatch[V]{}, false, errorhelper.CallerError(ErrNilSec)
+	}
+	m, found := firstMismatch(first, second, generichelper.DeepEqual[V])
+	return m, found, nil
+}
+
+// FirstMismatchEq returns the first difference between sequences yielded by two [iterator]s
+// by comparing their elements using a specified function.
+// If the sequences are equal, false is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func FirstMismatchEq[V any](first, second iter.Seq[V], equal func(V, V) bool) (Mismatch[V], bool, error) {
+	if first == nil || second == nil {
+		return Mismatch[V]{}, false, errorhelper.CallerError(ErrNilSec)
+	}
+	if equal == nil {
+		return Mismatch[V]{}, false, errorhelper.CallerError(ErrNilEqual)
+	}
+	m, found := firstMismatch(first, second, equal)
+	return m, found, nil
+}
+
+// FirstMismatch2 returns the first difference between sequences yielded by two [iterator]s
+// by comparing their pairs using [generichelper.DeepEqual].
+// If the sequences are equal, false is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func FirstMismatch2[K, V any](first, second iter.Seq2[K, V]) (Mismatch[generichelper.Tuple2[K, V]], bool, error) {
+	if first == nil || second == nil {
+		return Mismatch[generichelper.Tuple2[K, V]]{}, false, errorhelper.CallerError(ErrNilSec2)
+	}
+	m, found := firstMismatch(tupleSeq(first), tupleSeq(second), generichelper.DeepEqual[generichelper.Tuple2[K, V]])
+	return m, found, nil
+}
+
+// FirstMismatchEq2 returns the first difference between sequences yielded by two [iterator]s
+// by comparing their pairs using a specified function.
+// If the sequences are equal, false is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func FirstMismatchEq2[K, V any](first, second iter.Seq2[K, V],
+	equal func(k1 K, v1 V, k2 K, v2 V) bool) (Mismatch[generichelper.Tuple2[K, V]], bool, error) {
+	if first == nil || second == nil {
+		return Mismatch[generichelper.Tuple2[K, V]]{}, false, errorhelper.CallerError(ErrNilSec2)
+	}
+	if equal == nil {
+		return Mismatch[generichelper.Tuple2[K, V]]{}, false, errorhelper.CallerError(ErrNilEqual)
+	}
+	m, found := firstMismatch(tupleSeq(first), tupleSeq(second),
+		func(t1, t2 generichelper.Tuple2[K, V]) bool {
+			return equal(t1.Item1, t1.Item2, t2.Item1, t2.Item2)
+		})
+	return m, found, nil
+}
+
+func firstMismatch[V any](first, second iter.Seq[V], equal func(V, V) bool) (Mismatch[V], bool) {
+	next2, stop2 := iter.Pull(second)
+	defer stop2()
+	i := 0
+	for v1 := range first {
+		v2, ok2 := next2()
+		if !ok2 || !equal(v1, v2) {
+			return Mismatch[V]{Index: i, First: v1, Second: v2, SecondEnded: !ok2}, true
+		}
+		i++
+	}
+	if v2, ok2 := next2(); ok2 {
+		return Mismatch[V]{Index: i, Second: v2, FirstEnded: true}, true
+	}
+	return Mismatch[V]{}, false
+}
+
+// tupleSeq converts [iter.Seq2] to [iter.Seq] of tuples.
+func tupleSeq[K, V any](seq2 iter.Seq2[K, V]) iter.Seq[generichelper.Tuple2[K, V]] {
+	return func(yield func(generichelper.Tuple2[K, V]) bool) {
+		for k, v := range seq2 {
+			if !yield(generichelper.NewTuple2(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// EditOp is an operation of an [Edit].
+type EditOp int
+
+const (
+	// EditKeep means that the element is present in both sequences.
+	EditKeep EditOp = iota
+	// EditDelete means that the element is present in the first sequence only.
+	EditDelete
+	// EditInsert means that the element is present in the second sequence only.
+	EditInsert
+)
+
+// Edit is an element of an edit script that transforms one sequence into another.
+type Edit[V any] struct {
+	Op    EditOp
+	Value V
+}
+
+// EditScript returns the shortest edit script that transforms the sequence yielded by 'first'
+// into the sequence yielded by 'second' by comparing their elements using [generichelper.DeepEqual].
+// Both sequences are collected and the time and memory needed are proportional
+// to the product of the sequences' lengths, so EditScript is intended for small sequences.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func EditScript[V any](first, second iter.Seq[V]) ([]Edit[V], error) {
+	if first == nil || second == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	return editScript(slices.Collect(first), slices.Collect(second), generichelper.DeepEqual[V]), nil
+}
+
+// EditScriptEq returns the shortest edit script that transforms the sequence yielded by 'first'
+// into the sequence yielded by 'second' by comparing their elements using a specified function.
+// Both sequences are collected and the time and memory needed are proportional
+// to the product of the sequences' lengths, so EditScriptEq is intended for small sequences.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func EditScriptEq[V any](first, second iter.Seq[V], equal func(V, V) bool) ([]Edit[V], error) {
+	if first == nil || second == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if equal == nil {
+		return nil, errorhelper.CallerError(ErrNilEqual)
+	}
+	return editScript(slices.Collect(first), slices.Collect(second), equal), nil
+}
+
+// editScript builds the edit script from the longest common subsequence of 'a' and 'b'.
+func editScript[V any](a, b []V, equal func(V, V) bool) []Edit[V] {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if equal(a[i], b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var r []Edit[V]
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case equal(a[i], b[j]):
+			r = append(r, Edit[V]{Op: EditKeep, Value: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			r = append(r, Edit[V]{Op: EditDelete, Value: a[i]})
+			i++
+		default:
+			r = append(r, Edit[V]{Op: EditInsert, Value: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		r = append(r, Edit[V]{Op: EditDelete, Value: a[i]})
+	}
+	for ; j < len(b); j++ {
+		r = append(r, Edit[V]{Op: EditInsert, Value: b[j]})
+	}
+	return r
+}
@@ -0,0 +1,181 @@
+package iterhelper
+
+import (
+	"errors"
+	"iter"
+	"reflect"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestFirstMismatch_int(t *testing.T) {
+	type args struct {
+		first  iter.Seq[int]
+		second iter.Seq[int]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        Mismatch[int]
+		wantFound   bool
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSecond",
+			args:        args{first: Var(1), second: nil},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "EmptyEmpty",
+			args: args{first: Empty[int](), second: Empty[int]()},
+		},
+		{name: "Equal",
+			args: args{first: Var(1, 2, 3), second: intSeq(1, 3)},
+		},
+		{name: "UnequalData",
+			args:      args{first: Var(1, 5, 3, 9), second: Var(1, 5, 3, 10)},
+			want:      Mismatch[int]{Index: 3, First: 9, Second: 10},
+			wantFound: true,
+		},
+		{name: "FirstEnded",
+			args:      args{first: Var(1, 2), second: Var(1, 2, 3)},
+			want:      Mismatch[int]{Index: 2, Second: 3, FirstEnded: true},
+			wantFound: true,
+		},
+		{name: "SecondEnded",
+			args:      args{first: Var(1, 2, 3), second: Empty[int]()},
+			want:      Mismatch[int]{Index: 0, First: 1, SecondEnded: true},
+			wantFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := FirstMismatch(tt.args.first, tt.args.second)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FirstMismatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("FirstMismatch() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("FirstMismatch() = %+v, %v, want %+v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestFirstMismatchEq_string(t *testing.T) {
+	got, found, err := FirstMismatchEq(Var("one", "TWO", "three"), Var("ONE", "two", "four"), caseInsensitiveEqual)
+	if err != nil {
+		t.Fatalf("FirstMismatchEq() error = %v", err)
+	}
+	want := Mismatch[string]{Index: 2, First: "three", Second: "four"}
+	if !found || got != want {
+		t.Errorf("FirstMismatchEq() = %+v, %v, want %+v, %v", got, found, want, true)
+	}
+	if _, _, err := FirstMismatchEq(Var("a"), Var("a"), nil); !errors.Is(err, ErrNilEqual) {
+		t.Errorf("FirstMismatchEq() error = %v, expectedErr %v", err, ErrNilEqual)
+	}
+}
+
+func TestFirstMismatch2_int_string(t *testing.T) {
+	got, found, err := FirstMismatch2(sec2_int_string(3),
+		Var2Tuple(generichelper.NewTuple2(0, "0"), generichelper.NewTuple2(1, "one")))
+	if err != nil {
+		t.Fatalf("FirstMismatch2() error = %v", err)
+	}
+	want := Mismatch[generichelper.Tuple2[int, string]]{
+		Index:  1,
+		First:  generichelper.NewTuple2(1, "1"),
+		Second: generichelper.NewTuple2(1, "one"),
+	}
+	if !found || got != want {
+		t.Errorf("FirstMismatch2() = %+v, %v, want %+v, %v", got, found, want, true)
+	}
+	_, found, _ = FirstMismatch2(sec2_int_string(3), sec2_int_string(3))
+	if found {
+		t.Errorf("FirstMismatch2() found mismatch in equal sequences")
+	}
+}
+
+func TestFirstMismatchEq2_int_string(t *testing.T) {
+	_, found, err := FirstMismatchEq2(sec2_int_string(2),
+		Var2Tuple(generichelper.NewTuple2(5, "0"), generichelper.NewTuple2(6, "1")),
+		func(_ int, v1 string, _ int, v2 string) bool { return v1 == v2 })
+	if err != nil || found {
+		t.Errorf("FirstMismatchEq2() = %v, %v, want %v, %v", found, err, false, nil)
+	}
+}
+
+func TestEditScript_string(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  iter.Seq[string]
+		second iter.Seq[string]
+		want   []Edit[string]
+	}{
+		{name: "EmptyEmpty",
+			first:  Empty[string](),
+			second: Empty[string](),
+			want:   nil,
+		},
+		{name: "Insert",
+			first:  Empty[string](),
+			second: Var("a"),
+			want:   []Edit[string]{{Op: EditInsert, Value: "a"}},
+		},
+		{name: "Delete",
+			first:  Var("a"),
+			second: Empty[string](),
+			want:   []Edit[string]{{Op: EditDelete, Value: "a"}},
+		},
+		{name: "Mixed",
+			first:  Var("a", "b", "c", "d"),
+			second: Var("a", "c", "d", "e"),
+			want: []Edit[string]{
+				{Op: EditKeep, Value: "a"},
+				{Op: EditDelete, Value: "b"},
+				{Op: EditKeep, Value: "c"},
+				{Op: EditKeep, Value: "d"},
+				{Op: EditInsert, Value: "e"},
+			},
+		},
+		{name: "Replace",
+			first:  Var("a", "x", "c"),
+			second: Var("a", "y", "c"),
+			want: []Edit[string]{
+				{Op: EditKeep, Value: "a"},
+				{Op: EditDelete, Value: "x"},
+				{Op: EditInsert, Value: "y"},
+				{Op: EditKeep, Value: "c"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EditScript(tt.first, tt.second)
+			if err != nil {
+				t.Fatalf("EditScript() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EditScript() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditScriptEq_string(t *testing.T) {
+	got, err := EditScriptEq(Var("A", "b"), Var("a", "B"), caseInsensitiveEqual)
+	if err != nil {
+		t.Fatalf("EditScriptEq() error = %v", err)
+	}
+	want := []Edit[string]{{Op: EditKeep, Value: "A"}, {Op: EditKeep, Value: "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EditScriptEq() = %v, want %v", got, want)
+	}
+}
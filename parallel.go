@@ -0,0 +1,215 @@
+package iterhelper
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+type parallelResult[R any] struct {
+	r   R
+	err error
+}
+
+// yield yields the result, which is replaced by the zero value if 'fn' returned an error.
+func (res parallelResult[R]) yield(yield func(R, error) bool) bool {
+	if res.err != nil {
+		return yield(generichelper.ZeroValue[R](), errorhelper.CallerError(res.err))
+	}
+	return yield(res.r, nil)
+}
+
+// parallelWorkers tracks worker goroutines started by a producer goroutine,
+// which may be blocked inside the source [iterator] and therefore is not waited for.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+type parallelWorkers struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+// start runs 'f' in a new goroutine unless the workers are stopped.
+// It returns false if the workers are stopped.
+func (pw *parallelWorkers) start(f func()) bool {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	if pw.stopped {
+		return false
+	}
+	pw.wg.Add(1)
+	go func() {
+		defer pw.wg.Done()
+		f()
+	}()
+	return true
+}
+
+// stop prevents new workers from starting and waits for the started ones.
+func (pw *parallelWorkers) stop() {
+	pw.mu.Lock()
+	pw.stopped = true
+	pw.mu.Unlock()
+	pw.wg.Wait()
+}
+
+// ParallelMap returns a [SeqErr] of results of concurrent application of 'fn'
+// to each value yielded by the [iterator] 'seq'.
+// Results are yielded in the order of the source values.
+// At most 'workers' values are processed at a time and at most 'workers' finished results
+// wait for their turn to be yielded, so a slow value holds back further pulling from 'seq'.
+// An error returned by 'fn' is yielded along with the zero result and the iteration proceeds.
+// If 'ctx' is canceled, the iteration ends with the zero result and 'ctx' error.
+// If the consumer stops the iteration or 'ctx' is canceled, 'fn' contexts are canceled
+// and all started 'fn' calls are waited for.
+// 'seq' is ranged over in a separate goroutine, which is not waited for, since it may be blocked inside 'seq';
+// it finishes without pulling further values as soon as 'seq' yields the next value or ends.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func ParallelMap[V, R any](ctx context.Context, seq iter.Seq[V], workers int,
+	fn func(context.Context, V) (R, error)) (SeqErr[R], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if workers <= 0 {
+		return nil, errorhelper.CallerError(ErrNonPositiveWorkers)
+	}
+	if fn == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	return func(yield func(R, error) bool) {
+			cctx, cancel := context.WithCancel(ctx)
+			var pw parallelWorkers
+			defer pw.stop()
+			defer cancel()
+			// 'pending' holds result slots in the order of the source values
+			pending := make(chan chan parallelResult[R], workers)
+			sem := make(chan struct{}, workers)
+			go func() {
+				defer close(pending)
+				for v := range seq {
+					select {
+					case <-cctx.Done():
+						return
+					case sem <- struct{}{}:
+					}
+					slot := make(chan parallelResult[R], 1)
+					if !pw.start(func() {
+						r, err := fn(cctx, v)
+						slot <- parallelResult[R]{r: r, err: err}
+						<-sem
+					}) {
+						return
+					}
+					select {
+					case <-cctx.Done():
+						return
+					case pending <- slot:
+					}
+				}
+			}()
+			for {
+				var slot chan parallelResult[R]
+				select {
+				case <-cctx.Done():
+					yield(generichelper.ZeroValue[R](), errorhelper.CallerError(ctx.Err()))
+					return
+				case s, ok := <-pending:
+					if !ok {
+						// the producer may have stopped because of 'ctx'
+						if ctx.Err() != nil {
+							yield(generichelper.ZeroValue[R](), errorhelper.CallerError(ctx.Err()))
+						}
+						return
+					}
+					slot = s
+				}
+				select {
+				case <-cctx.Done():
+					yield(generichelper.ZeroValue[R](), errorhelper.CallerError(ctx.Err()))
+					return
+				case res := <-slot:
+					if !res.yield(yield) {
+						return
+					}
+				}
+			}
+		},
+		nil
+}
+
+// ParallelMapUnordered returns a [SeqErr] of results of concurrent application of 'fn'
+// to each value yielded by the [iterator] 'seq'.
+// Results are yielded in the order of their completion.
+// At most 'workers' values are processed at a time.
+// Otherwise ParallelMapUnordered behaves like [ParallelMap].
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func ParallelMapUnordered[V, R any](ctx context.Context, seq iter.Seq[V], workers int,
+	fn func(context.Context, V) (R, error)) (SeqErr[R], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if workers <= 0 {
+		return nil, errorhelper.CallerError(ErrNonPositiveWorkers)
+	}
+	if fn == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	return func(yield func(R, error) bool) {
+			cctx, cancel := context.WithCancel(ctx)
+			var pw parallelWorkers
+			defer pw.stop()
+			defer cancel()
+			results := make(chan parallelResult[R], workers)
+			sem := make(chan struct{}, workers)
+			go func() {
+				// 'results' is closed after all the workers started by this goroutine are finished
+				var wg sync.WaitGroup
+				defer close(results)
+				defer wg.Wait()
+				for v := range seq {
+					select {
+					case <-cctx.Done():
+						return
+					case sem <- struct{}{}:
+					}
+					wg.Add(1)
+					if !pw.start(func() {
+						defer wg.Done()
+						defer func() { <-sem }()
+						r, err := fn(cctx, v)
+						select {
+						case <-cctx.Done():
+						case results <- parallelResult[R]{r: r, err: err}:
+						}
+					}) {
+						wg.Done()
+						return
+					}
+				}
+			}()
+			for {
+				select {
+				case <-cctx.Done():
+					yield(generichelper.ZeroValue[R](), errorhelper.CallerError(ctx.Err()))
+					return
+				case res, ok := <-results:
+					if !ok {
+						// the producer may have stopped because of 'ctx'
+						if ctx.Err() != nil {
+							yield(generichelper.ZeroValue[R](), errorhelper.CallerError(ctx.Err()))
+						}
+						return
+					}
+					if !res.yield(yield) {
+						return
+					}
+				}
+			}
+		},
+		nil
+}
@@ -0,0 +1,291 @@
+package iterhelper
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func square(_ context.Context, i int) (int, error) {
+	// later values finish earlier
+	time.Sleep(time.Duration(10-i%10) * time.Millisecond)
+	return i * i, nil
+}
+
+func TestParallelMap_int(t *testing.T) {
+	type args struct {
+		seq     iter.Seq[int]
+		workers int
+		fn      func(context.Context, int) (int, error)
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        []int
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSource",
+			args: args{
+				seq:     nil,
+				workers: 2,
+				fn:      square,
+			},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "NonPositiveWorkers",
+			args: args{
+				seq:     Var(1, 2, 3),
+				workers: 0,
+				fn:      square,
+			},
+			wantErr:     true,
+			expectedErr: ErrNonPositiveWorkers,
+		},
+		{name: "NilFn",
+			args: args{
+				seq:     Var(1, 2, 3),
+				workers: 2,
+				fn:      nil,
+			},
+			wantErr:     true,
+			expectedErr: ErrNilSelector,
+		},
+		{name: "Empty",
+			args: args{
+				seq:     Empty[int](),
+				workers: 2,
+				fn:      square,
+			},
+			want: nil,
+		},
+		{name: "Regular",
+			args: args{
+				seq:     intSeq(0, 20),
+				workers: 4,
+				fn:      square,
+			},
+			want: []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81, 100, 121, 144, 169, 196, 225, 256, 289, 324, 361},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParallelMap(context.Background(), tt.args.seq, tt.args.workers, tt.args.fn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParallelMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("ParallelMap() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			var rr []int
+			for r, err := range got {
+				if err != nil {
+					t.Fatalf("ParallelMap() element error = %v", err)
+				}
+				rr = append(rr, r)
+			}
+			if !slices.Equal(rr, tt.want) {
+				t.Errorf("ParallelMap() = %v, want %v", rr, tt.want)
+			}
+		})
+	}
+}
+
+func TestParallelMap_elementError(t *testing.T) {
+	got, _ := ParallelMap(context.Background(), Var(1, 2, 3), 2,
+		func(_ context.Context, i int) (int, error) {
+			if i == 2 {
+				// the result returned along with the error is not yielded
+				return 99, ErrTestError
+			}
+			return i, nil
+		})
+	var rr []int
+	var ee []error
+	for r, err := range got {
+		rr = append(rr, r)
+		ee = append(ee, err)
+	}
+	if !slices.Equal(rr, []int{1, 0, 3}) {
+		t.Errorf("ParallelMap() = %v, want %v", rr, []int{1, 0, 3})
+	}
+	if ee[0] != nil || !errors.Is(ee[1], ErrTestError) || ee[2] != nil {
+		t.Errorf("ParallelMap() errors = %v", ee)
+	}
+}
+
+func TestParallelMap_break(t *testing.T) {
+	var active int64
+	got, _ := ParallelMap(context.Background(), intSeq(0, 1000), 4,
+		func(ctx context.Context, i int) (int, error) {
+			atomic.AddInt64(&active, 1)
+			defer atomic.AddInt64(&active, -1)
+			if i > 0 {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+			return i, nil
+		})
+	for range got {
+		break
+	}
+	if n := atomic.LoadInt64(&active); n != 0 {
+		t.Errorf("ParallelMap() left %v active goroutines after break", n)
+	}
+}
+
+func TestParallelMap_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	got, _ := ParallelMap(ctx, intSeq(0, 1000), 2,
+		func(_ context.Context, i int) (int, error) {
+			if i == 5 {
+				cancel()
+			}
+			return i, nil
+		})
+	var last error
+	n := 0
+	for _, err := range got {
+		last = err
+		n++
+	}
+	if !errors.Is(last, context.Canceled) {
+		t.Errorf("ParallelMap() last error = %v, expectedErr %v", last, context.Canceled)
+	}
+	if n >= 1000 {
+		t.Errorf("ParallelMap() yielded %v results after cancellation", n)
+	}
+}
+
+func TestParallelMapUnordered_int(t *testing.T) {
+	got, err := ParallelMapUnordered(context.Background(), intSeq(0, 20), 4, square)
+	if err != nil {
+		t.Fatalf("ParallelMapUnordered() error = %v", err)
+	}
+	var rr []int
+	for r, err := range got {
+		if err != nil {
+			t.Fatalf("ParallelMapUnordered() element error = %v", err)
+		}
+		rr = append(rr, r)
+	}
+	slices.Sort(rr)
+	want := []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81, 100, 121, 144, 169, 196, 225, 256, 289, 324, 361}
+	if !slices.Equal(rr, want) {
+		t.Errorf("ParallelMapUnordered() = %v, want %v", rr, want)
+	}
+}
+
+func TestParallelMapUnordered_elementError(t *testing.T) {
+	got, _ := ParallelMapUnordered(context.Background(), Var(2), 1,
+		func(context.Context, int) (int, error) {
+			return 99, ErrTestError
+		})
+	n := 0
+	for r, err := range got {
+		n++
+		if r != 0 || !errors.Is(err, ErrTestError) {
+			t.Errorf("ParallelMapUnordered() = %v, %v, want %v, %v", r, err, 0, ErrTestError)
+		}
+	}
+	if n != 1 {
+		t.Errorf("ParallelMapUnordered() yielded %v results, want %v", n, 1)
+	}
+}
+
+func TestParallelMapUnordered_break(t *testing.T) {
+	var active int64
+	got, _ := ParallelMapUnordered(context.Background(), intSeq(0, 1000), 4,
+		func(ctx context.Context, i int) (int, error) {
+			atomic.AddInt64(&active, 1)
+			defer atomic.AddInt64(&active, -1)
+			if i > 0 {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+			return i, nil
+		})
+	for range got {
+		break
+	}
+	if n := atomic.LoadInt64(&active); n != 0 {
+		t.Errorf("ParallelMapUnordered() left %v active goroutines after break", n)
+	}
+}
+
+// idleSource returns a sequence that yields 'v' and then blocks until 'c' is closed.
+func idleSource(v int) (iter.Seq[int], func()) {
+	c := make(chan int, 1)
+	c <- v
+	return ChanAll(c), func() { close(c) }
+}
+
+// finishes fails the test if 'f' does not return in time.
+func finishes(t *testing.T, name string, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("%s blocked", name)
+	}
+}
+
+func passInt(_ context.Context, i int) (int, error) {
+	return i, nil
+}
+
+func TestParallelMap_blockedSource(t *testing.T) {
+	for _, pm := range []struct {
+		name string
+		f    func(context.Context, iter.Seq[int], int, func(context.Context, int) (int, error)) (SeqErr[int], error)
+	}{
+		{name: "ParallelMap", f: ParallelMap[int, int]},
+		{name: "ParallelMapUnordered", f: ParallelMapUnordered[int, int]},
+	} {
+		t.Run(pm.name+"_break", func(t *testing.T) {
+			seq, release := idleSource(1)
+			defer release()
+			got, _ := pm.f(context.Background(), seq, 2, passInt)
+			finishes(t, pm.name, func() {
+				for range got {
+					break
+				}
+			})
+		})
+		t.Run(pm.name+"_canceled", func(t *testing.T) {
+			seq, release := idleSource(1)
+			defer release()
+			ctx, cancel := context.WithCancel(context.Background())
+			got, _ := pm.f(ctx, seq, 2, passInt)
+			var rr []int
+			var last error
+			finishes(t, pm.name, func() {
+				for r, err := range got {
+					if err != nil {
+						last = err
+						continue
+					}
+					rr = append(rr, r)
+					cancel()
+				}
+			})
+			if !slices.Equal(rr, []int{1}) || !errors.Is(last, context.Canceled) {
+				t.Errorf("%s() = %v, %v, want %v, %v", pm.name, rr, last, []int{1}, context.Canceled)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package iterhelper
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// fmtParser splits a string rendered according to [Format] into elements.
+type fmtParser struct {
+	format Format
+	s      string
+	i      int
+	// sep is true if an element separator has just been consumed
+	sep bool
+}
+
+func newFmtParser(s string, format Format) (*fmtParser, error) {
+	body, ok := strings.CutPrefix(s, format.LeftEdge)
+	if !ok {
+		return nil, fmt.Errorf("%w: no left edge %q", ErrMalformedString, format.LeftEdge)
+	}
+	body, ok = strings.CutSuffix(body, format.RightEdge)
+	if !ok {
+		return nil, fmt.Errorf("%w: no right edge %q", ErrMalformedString, format.RightEdge)
+	}
+	return &fmtParser{format: format, s: body}, nil
+}
+
+func (p *fmtParser) malformed(what string) error {
+	return fmt.Errorf("%w: %s at position %d", ErrMalformedString, what, len(p.format.LeftEdge)+p.i)
+}
+
+// more reports whether there are elements left.
+func (p *fmtParser) more() bool {
+	return p.i < len(p.s) || p.sep
+}
+
+// element returns the unescaped content of the next element.
+// If 'pair' is true, the content is split at the first unescaped value separator.
+func (p *fmtParser) element(pair bool) (key, value string, err error) {
+	if !strings.HasPrefix(p.s[p.i:], p.format.LeftRim) {
+		return "", "", p.malformed(fmt.Sprintf("no left rim %q", p.format.LeftRim))
+	}
+	p.i += len(p.format.LeftRim)
+	p.sep = false
+	var b strings.Builder
+	split, closed := false, false
+	for p.i < len(p.s) {
+		rest := p.s[p.i:]
+		if p.format.Escape != "" && strings.HasPrefix(rest, p.format.Escape) {
+			p.i += len(p.format.Escape)
+			if p.i == len(p.s) {
+				return "", "", p.malformed("dangling escape")
+			}
+			r, size := utf8.DecodeRuneInString(p.s[p.i:])
+			b.WriteRune(r)
+			p.i += size
+			continue
+		}
+		if p.format.RightRim != "" {
+			if strings.HasPrefix(rest, p.format.RightRim) {
+				p.i += len(p.format.RightRim)
+				closed = true
+				break
+			}
+		} else if p.format.ElementSeparator != "" && strings.HasPrefix(rest, p.format.ElementSeparator) {
+			break
+		}
+		if pair && !split && strings.HasPrefix(rest, p.format.ValueSeparator) {
+			key = b.String()
+			b.Reset()
+			p.i += len(p.format.ValueSeparator)
+			split = true
+			continue
+		}
+		b.WriteByte(p.s[p.i])
+		p.i++
+	}
+	if p.format.RightRim != "" && !closed {
+		return "", "", p.malformed(fmt.Sprintf("no right rim %q", p.format.RightRim))
+	}
+	if pair && !split {
+		return "", "", p.malformed(fmt.Sprintf("no value separator %q", p.format.ValueSeparator))
+	}
+	if p.i < len(p.s) {
+		if p.format.ElementSeparator != "" {
+			if !strings.HasPrefix(p.s[p.i:], p.format.ElementSeparator) {
+				return "", "", p.malformed(fmt.Sprintf("no element separator %q", p.format.ElementSeparator))
+			}
+			p.i += len(p.format.ElementSeparator)
+			p.sep = true
+		}
+	}
+	return key, b.String(), nil
+}
+
+// ParseFmt returns a [SeqErr] of values parsed from 's' rendered by [StringFmt] or [WriteFmt] with 'format'.
+// 's' is split into elements according to edges, rims and element separator of 'format',
+// escape sequences (see [Format.Escape]) are resolved and each element is converted by 'parse'.
+// An error returned by 'parse' is yielded along with the zero value and the iteration proceeds.
+// If 's' does not conform to 'format', [ErrMalformedString] is yielded and the iteration ends.
+// Strings rendered with [Format.MaxElements] or [Format.MaxLength] cannot be parsed back.
+// 'format' Escape must not start with the same rune as a rim or a separator, otherwise parsing is ambiguous.
+// If rims are empty, a single empty element is indistinguishable from no elements, so it is not yielded.
+func ParseFmt[V any](s string, format Format, parse func(string) (V, error)) (SeqErr[V], error) {
+	if parse == nil {
+		return nil, errorhelper.CallerError(ErrNilParse)
+	}
+	return func(yield func(V, error) bool) {
+			p, err := newFmtParser(s, format)
+			if err != nil {
+				yield(generichelper.ZeroValue[V](), errorhelper.CallerError(err))
+				return
+			}
+			for p.more() {
+				_, e, err := p.element(false)
+				if err != nil {
+					yield(generichelper.ZeroValue[V](), errorhelper.CallerError(err))
+					return
+				}
+				v, err := parse(e)
+				if err != nil {
+					if !yield(generichelper.ZeroValue[V](), errorhelper.CallerError(err)) {
+						return
+					}
+					continue
+				}
+				if !yield(v, nil) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// ParseFmt2 returns a [SeqErr] of key-value pairs parsed from 's' rendered by [StringFmt2] or [WriteFmt2] with 'format'.
+// Each element is split at the first unescaped value separator,
+// the parts are converted by 'parseKey' and 'parseValue' respectively.
+// Otherwise ParseFmt2 behaves like [ParseFmt].
+func ParseFmt2[K, V any](s string, format Format,
+	parseKey func(string) (K, error), parseValue func(string) (V, error)) (SeqErr[generichelper.Tuple2[K, V]], error) {
+	if parseKey == nil || parseValue == nil {
+		return nil, errorhelper.CallerError(ErrNilParse)
+	}
+	return func(yield func(generichelper.Tuple2[K, V], error) bool) {
+			zero := generichelper.ZeroValue[generichelper.Tuple2[K, V]]()
+			if format.ValueSeparator == "" {
+				yield(zero, errorhelper.CallerError(fmt.Errorf("%w: empty value separator", ErrMalformedString)))
+				return
+			}
+			p, err := newFmtParser(s, format)
+			if err != nil {
+				yield(zero, errorhelper.CallerError(err))
+				return
+			}
+			for p.more() {
+				ek, ev, err := p.element(true)
+				if err != nil {
+					yield(zero, errorhelper.CallerError(err))
+					return
+				}
+				k, err := parseKey(ek)
+				if err != nil {
+					if !yield(zero, errorhelper.CallerError(err)) {
+						return
+					}
+					continue
+				}
+				v, err := parseValue(ev)
+				if err != nil {
+					if !yield(zero, errorhelper.CallerError(err)) {
+						return
+					}
+					continue
+				}
+				if !yield(generichelper.NewTuple2(k, v), nil) {
+					return
+				}
+			}
+		},
+		nil
+}
@@ -0,0 +1,233 @@
+package iterhelper
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func TestParseFmt_string(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		format      Format
+		want        []string
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "Empty",
+			s:      "[]",
+			format: DefaultFormat,
+			want:   nil,
+		},
+		{name: "Default",
+			s:      "[a b c]",
+			format: DefaultFormat,
+			want:   []string{"a", "b", "c"},
+		},
+		{name: "NoLeftEdge",
+			s:           "a b]",
+			format:      DefaultFormat,
+			wantErr:     true,
+			expectedErr: ErrMalformedString,
+		},
+		{name: "NoRightEdge",
+			s:           "[a b",
+			format:      DefaultFormat,
+			wantErr:     true,
+			expectedErr: ErrMalformedString,
+		},
+		{name: "TrailingSeparator",
+			s:      "[a b ]",
+			format: DefaultFormat,
+			want:   []string{"a", "b", ""},
+		},
+		{name: "EmptyElements",
+			s:      "[,,x]",
+			format: Format{ElementSeparator: ",", LeftEdge: "[", RightEdge: "]"},
+			want:   []string{"", "", "x"},
+		},
+		{name: "Rims",
+			s:      `("a b"; "";"c")`,
+			format: Format{LeftRim: `"`, RightRim: `"`, ElementSeparator: ";", LeftEdge: "(", RightEdge: ")"},
+			// ' ' after separator is not a left rim
+			want:        []string{"a b"},
+			wantErr:     true,
+			expectedErr: ErrMalformedString,
+		},
+		{name: "RimsNoSeparator",
+			s:      "<a><b c><>",
+			format: Format{LeftRim: "<", RightRim: ">"},
+			want:   []string{"a", "b c", ""},
+		},
+		{name: "NoRightRim",
+			s:           `"a","b`,
+			format:      Format{LeftRim: `"`, RightRim: `"`, ElementSeparator: ","},
+			want:        []string{"a"},
+			wantErr:     true,
+			expectedErr: ErrMalformedString,
+		},
+		{name: "Escape",
+			s:      `[a\ b c\\ \x]`,
+			format: Format{ElementSeparator: " ", LeftEdge: "[", RightEdge: "]", Escape: `\`},
+			want:   []string{"a b", `c\`, "x"},
+		},
+		{name: "DanglingEscape",
+			s:           `a,b\`,
+			format:      Format{ElementSeparator: ",", Escape: `\`},
+			want:        []string{"a"},
+			wantErr:     true,
+			expectedErr: ErrMalformedString,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqErr, err := ParseFmt(tt.s, tt.format, parseString)
+			if err != nil {
+				t.Fatalf("ParseFmt() error = %v", err)
+			}
+			got, err := TryCollect(seqErr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFmt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("ParseFmt() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseFmt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFmt_parseError(t *testing.T) {
+	if _, err := ParseFmt[int]("[]", DefaultFormat, nil); !errors.Is(err, ErrNilParse) {
+		t.Errorf("ParseFmt() error = %v, expectedErr %v", err, ErrNilParse)
+	}
+	seqErr, _ := ParseFmt("[1 x 3]", DefaultFormat, strconv.Atoi)
+	var vv []int
+	var ee []error
+	for v, err := range seqErr {
+		vv = append(vv, v)
+		ee = append(ee, err)
+	}
+	if want := []int{1, 0, 3}; !slices.Equal(vv, want) {
+		t.Errorf("ParseFmt() = %v, want %v", vv, want)
+	}
+	if ee[0] != nil || !errors.Is(ee[1], strconv.ErrSyntax) || ee[2] != nil {
+		t.Errorf("ParseFmt() errors = %v", ee)
+	}
+}
+
+func TestParseFmt_roundTrip(t *testing.T) {
+	formats := []Format{
+		{ElementSeparator: " ", LeftEdge: "[", RightEdge: "]", Escape: `\`},
+		{LeftRim: `"`, RightRim: `"`, ElementSeparator: ", ", LeftEdge: "{", RightEdge: "}", Escape: `\`},
+		{LeftRim: "<", RightRim: ">", Escape: "<<"},
+		{ElementSeparator: "::", Escape: "!"},
+	}
+	// the leading empty element relies on separators being written between empty elements
+	ss := []string{"", "a b", `c\`, `"d"`, "<e>", "f::g", ":", "", "h, i", "j]"}
+	for _, format := range formats {
+		s := StringFmt(Var(ss...), format)
+		seqErr, _ := ParseFmt(s, format, parseString)
+		got, err := TryCollect(seqErr)
+		if err != nil {
+			t.Errorf("ParseFmt(%q) error = %v", s, err)
+			continue
+		}
+		if !slices.Equal(got, ss) {
+			t.Errorf("ParseFmt(%q) = %q, want %q", s, got, ss)
+		}
+	}
+}
+
+func TestParseFmt2_int_string(t *testing.T) {
+	if _, err := ParseFmt2[int, string]("[]", DefaultFormat, strconv.Atoi, nil); !errors.Is(err, ErrNilParse) {
+		t.Errorf("ParseFmt2() error = %v, expectedErr %v", err, ErrNilParse)
+	}
+	tests := []struct {
+		name        string
+		s           string
+		format      Format
+		want        []generichelper.Tuple2[int, string]
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "Default",
+			s:      StringDef2(sec2_int_string(3)),
+			format: DefaultFormat,
+			want:   Collect2Tuple(sec2_int_string(3)),
+		},
+		{name: "EmptyValueSeparator",
+			s:           "[1 2]",
+			format:      Format{ElementSeparator: " ", LeftEdge: "[", RightEdge: "]"},
+			wantErr:     true,
+			expectedErr: ErrMalformedString,
+		},
+		{name: "NoValueSeparator",
+			s:           "[1:a 2]",
+			format:      DefaultFormat,
+			want:        []generichelper.Tuple2[int, string]{generichelper.NewTuple2(1, "a")},
+			wantErr:     true,
+			expectedErr: ErrMalformedString,
+		},
+		{name: "SeparatorInValue",
+			s:      "[1:a:b 2:]",
+			format: DefaultFormat,
+			want: []generichelper.Tuple2[int, string]{
+				generichelper.NewTuple2(1, "a:b"), generichelper.NewTuple2(2, "")},
+		},
+		{name: "Escape",
+			s:      `(1=a\ b;2=\=)`,
+			format: Format{ElementSeparator: ";", LeftEdge: "(", RightEdge: ")", ValueSeparator: "=", Escape: `\`},
+			want: []generichelper.Tuple2[int, string]{
+				generichelper.NewTuple2(1, "a b"), generichelper.NewTuple2(2, "=")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqErr, err := ParseFmt2(tt.s, tt.format, strconv.Atoi, parseString)
+			if err != nil {
+				t.Fatalf("ParseFmt2() error = %v", err)
+			}
+			got, err := TryCollect(seqErr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFmt2() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("ParseFmt2() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFmt2() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFmt2_roundTrip(t *testing.T) {
+	format := Format{LeftRim: "(", RightRim: ")", ElementSeparator: ",", ValueSeparator: "=", Escape: "%"}
+	want := []generichelper.Tuple2[string, string]{
+		generichelper.NewTuple2("a=b", "(c)"),
+		generichelper.NewTuple2("", "d,e%"),
+	}
+	s := StringFmt2(Var2Tuple(want...), format)
+	seqErr, _ := ParseFmt2(s, format, parseString, parseString)
+	got, err := TryCollect(seqErr)
+	if err != nil {
+		t.Fatalf("ParseFmt2(%q) error = %v", s, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFmt2(%q) = %v, want %v", s, got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package iterhelper
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// ScanSeq returns a [SeqErr] over tokens read from 'r' by [bufio.Scanner] split by 'split'.
+// 'maxTokenSize' is the maximum size of a token, if it is not positive, [bufio.MaxScanTokenSize] is used.
+// A token longer than 'maxTokenSize' makes the scanner fail with [bufio.ErrTooLong].
+// If the scanner fails, the zero value and the scanner error are yielded last.
+// Tokens are read as they are pulled, so the returned [SeqErr] can be iterated only once.
+func ScanSeq(r io.Reader, split bufio.SplitFunc, maxTokenSize int) (SeqErr[string], error) {
+	if r == nil {
+		return nil, errorhelper.CallerError(ErrNilReader)
+	}
+	if split == nil {
+		return nil, errorhelper.CallerError(ErrNilSplit)
+	}
+	return scanSeq(r, split, maxTokenSize, func(b []byte) string { return string(b) }), nil
+}
+
+// Lines returns a [SeqErr] over lines read from 'r' with end-of-line markers stripped (see [bufio.ScanLines]).
+// Lines not longer than [bufio.MaxScanTokenSize] are supported, use [ScanSeq] for longer ones.
+// Otherwise Lines behaves like [ScanSeq].
+func Lines(r io.Reader) (SeqErr[string], error) {
+	if r == nil {
+		return nil, errorhelper.CallerError(ErrNilReader)
+	}
+	return scanSeq(r, bufio.ScanLines, 0, func(b []byte) string { return string(b) }), nil
+}
+
+// Words returns a [SeqErr] over space-separated words read from 'r' (see [bufio.ScanWords]).
+// Otherwise Words behaves like [Lines].
+func Words(r io.Reader) (SeqErr[string], error) {
+	if r == nil {
+		return nil, errorhelper.CallerError(ErrNilReader)
+	}
+	return scanSeq(r, bufio.ScanWords, 0, func(b []byte) string { return string(b) }), nil
+}
+
+// Runes returns a [SeqErr] over runes read from 'r'.
+// An invalid UTF-8 encoding is yielded as [utf8.RuneError] (see [bufio.ScanRunes]).
+// Otherwise Runes behaves like [ScanSeq].
+func Runes(r io.Reader) (SeqErr[rune], error) {
+	if r == nil {
+		return nil, errorhelper.CallerError(ErrNilReader)
+	}
+	return scanSeq(r, bufio.ScanRunes, 0, func(b []byte) rune {
+			r, _ := utf8.DecodeRune(b)
+			return r
+		}),
+		nil
+}
+
+func scanSeq[V any](r io.Reader, split bufio.SplitFunc, maxTokenSize int, convert func([]byte) V) SeqErr[V] {
+	return func(yield func(V, error) bool) {
+		sc := bufio.NewScanner(r)
+		sc.Split(split)
+		if maxTokenSize > 0 {
+			sc.Buffer(make([]byte, 0, min(maxTokenSize, 4096)), maxTokenSize)
+		}
+		for sc.Scan() {
+			if !yield(convert(sc.Bytes()), nil) {
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			yield(generichelper.ZeroValue[V](), errorhelper.CallerError(err))
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package iterhelper
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// endlessLines returns "line\n" on each Read and counts the calls.
+type endlessLines struct {
+	reads int
+}
+
+func (el *endlessLines) Read(p []byte) (int, error) {
+	el.reads++
+	return copy(p, "line\n"), nil
+}
+
+func TestScanSeq(t *testing.T) {
+	if _, err := ScanSeq(nil, bufio.ScanLines, 0); !errors.Is(err, ErrNilReader) {
+		t.Errorf("ScanSeq() error = %v, expectedErr %v", err, ErrNilReader)
+	}
+	if _, err := ScanSeq(strings.NewReader(""), nil, 0); !errors.Is(err, ErrNilSplit) {
+		t.Errorf("ScanSeq() error = %v, expectedErr %v", err, ErrNilSplit)
+	}
+	long := strings.Repeat("x", 100)
+	tests := []struct {
+		name         string
+		r            io.Reader
+		maxTokenSize int
+		want         []string
+		wantErr      error
+	}{
+		{name: "Empty",
+			r: strings.NewReader(""),
+		},
+		{name: "Regular",
+			r:    strings.NewReader("a\r\n\nb c\nd"),
+			want: []string{"a", "", "b c", "d"},
+		},
+		{name: "LongLine",
+			r:            strings.NewReader("a\n" + long + "\nb"),
+			maxTokenSize: 101,
+			want:         []string{"a", long, "b"},
+		},
+		{name: "TooLong",
+			r:            strings.NewReader("a\n" + long + "\nb"),
+			maxTokenSize: 50,
+			want:         []string{"a", ""},
+			wantErr:      bufio.ErrTooLong,
+		},
+		{name: "ReadError",
+			r:       io.MultiReader(strings.NewReader("a\nb"), errReader{}),
+			want:    []string{"a", "b", ""},
+			wantErr: ErrTestError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqErr, err := ScanSeq(tt.r, bufio.ScanLines, tt.maxTokenSize)
+			if err != nil {
+				t.Fatalf("ScanSeq() error = %v", err)
+			}
+			got, errs := collectErr(seqErr)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ScanSeq() = %q, want %q", got, tt.want)
+			}
+			for i, err := range errs {
+				if i < len(errs)-1 || tt.wantErr == nil {
+					if err != nil {
+						t.Errorf("ScanSeq() error[%d] = %v, want nil", i, err)
+					}
+				} else if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ScanSeq() final error = %v, want %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestLines_break(t *testing.T) {
+	el := &endlessLines{}
+	seqErr, err := Lines(el)
+	if err != nil {
+		t.Fatalf("Lines() error = %v", err)
+	}
+	n := 0
+	for line, err := range seqErr {
+		if err != nil || line != "line" {
+			t.Fatalf("Lines() = %q, %v", line, err)
+		}
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if el.reads > 3 {
+		t.Errorf("Lines() made %v reads for 3 lines", el.reads)
+	}
+}
+
+func TestWords(t *testing.T) {
+	if _, err := Words(nil); !errors.Is(err, ErrNilReader) {
+		t.Errorf("Words() error = %v, expectedErr %v", err, ErrNilReader)
+	}
+	seqErr, _ := Words(strings.NewReader("  one two\n\tthree  \n"))
+	got, err := TryCollect(seqErr)
+	if err != nil {
+		t.Fatalf("Words() error = %v", err)
+	}
+	if want := []string{"one", "two", "three"}; !slices.Equal(got, want) {
+		t.Errorf("Words() = %q, want %q", got, want)
+	}
+}
+
+func TestRunes(t *testing.T) {
+	if _, err := Runes(nil); !errors.Is(err, ErrNilReader) {
+		t.Errorf("Runes() error = %v, expectedErr %v", err, ErrNilReader)
+	}
+	seqErr, _ := Runes(strings.NewReader("aж\xff😀"))
+	got, err := TryCollect(seqErr)
+	if err != nil {
+		t.Fatalf("Runes() error = %v", err)
+	}
+	if want := []rune{'a', 'ж', utf8.RuneError, '😀'}; !slices.Equal(got, want) {
+		t.Errorf("Runes() = %q, want %q", got, want)
+	}
+}
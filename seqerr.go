@@ -0,0 +1,154 @@
+package iterhelper
+
+import (
+	"context"
+	"iter"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// SeqErr is an [iterator] over a sequence of values, each of which may be accompanied by an error.
+// A non-nil error means that the corresponding value is not valid
+// and (as a rule) that the source failed and the sequence is about to end.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+type SeqErr[V any] = iter.Seq2[V, error]
+
+// TryCollect returns a slice of values yielded by the [SeqErr]
+// until the first non-nil error, which is returned along with the values collected so far.
+func TryCollect[V any](seqErr SeqErr[V]) ([]V, error) {
+	if seqErr == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	var r []V
+	for v, err := range seqErr {
+		if err != nil {
+			return r, errorhelper.CallerError(err)
+		}
+		r = append(r, v)
+	}
+	return r, nil
+}
+
+// ForEachErr sequentially performs a specified 'action' on each value yielded by the [SeqErr].
+// If 'ctx' is canceled, the [SeqErr] yields a non-nil error or 'action' returns a non-nil error,
+// the operation is stopped and corresponding error is returned.
+func ForEachErr[V any](ctx context.Context, seqErr SeqErr[V], action func(V) error) error {
+	if seqErr == nil {
+		return errorhelper.CallerError(ErrNilSec2)
+	}
+	if action == nil {
+		return errorhelper.CallerError(ErrNilAction)
+	}
+	for v, err := range seqErr {
+		if err != nil {
+			return errorhelper.CallerError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return errorhelper.CallerError(ctx.Err())
+		default:
+			if err := action(v); err != nil {
+				return errorhelper.CallerError(err)
+			}
+		}
+	}
+	return nil
+}
+
+// MapErr converts the [SeqErr] to the [SeqErr] of values returned by 'selector'.
+// Errors yielded by 'seqErr' are passed through along with the zero value, 'selector' is not called for them.
+// Errors returned by 'selector' are yielded along with the zero value.
+func MapErr[V, R any](seqErr SeqErr[V], selector func(V) (R, error)) (SeqErr[R], error) {
+	if seqErr == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if selector == nil {
+		return nil, errorhelper.CallerError(ErrNilSelector)
+	}
+	return func(yield func(R, error) bool) {
+			for v, err := range seqErr {
+				if err != nil {
+					if !yield(generichelper.ZeroValue[R](), err) {
+						return
+					}
+					continue
+				}
+				r, err := selector(v)
+				if err != nil {
+					if !yield(generichelper.ZeroValue[R](), errorhelper.CallerError(err)) {
+						return
+					}
+					continue
+				}
+				if !yield(r, nil) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// FilterErr returns the [SeqErr] of values of 'seqErr' that satisfy 'predicate'.
+// Errors yielded by 'seqErr' are passed through, 'predicate' is not called for them.
+// Errors returned by 'predicate' are yielded along with the tested value.
+func FilterErr[V any](seqErr SeqErr[V], predicate func(V) (bool, error)) (SeqErr[V], error) {
+	if seqErr == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if predicate == nil {
+		return nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	return func(yield func(V, error) bool) {
+			for v, err := range seqErr {
+				if err != nil {
+					if !yield(v, err) {
+						return
+					}
+					continue
+				}
+				ok, err := predicate(v)
+				if err != nil {
+					if !yield(v, errorhelper.CallerError(err)) {
+						return
+					}
+					continue
+				}
+				if ok && !yield(v, nil) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// SplitErr splits the [SeqErr] into an [iterator] over values
+// and a function returning the error that ended the iteration.
+// The [iterator] stops at the first non-nil error yielded by 'seqErr'.
+// The returned function must be called after the iteration is over;
+// it returns nil if 'seqErr' has been exhausted without errors.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func SplitErr[V any](seqErr SeqErr[V]) (iter.Seq[V], func() error, error) {
+	if seqErr == nil {
+		return nil, nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	var last error
+	return func(yield func(V) bool) {
+			last = nil
+			for v, err := range seqErr {
+				if err != nil {
+					last = err
+					return
+				}
+				if !yield(v) {
+					return
+				}
+			}
+		},
+		func() error {
+			return last
+		},
+		nil
+}
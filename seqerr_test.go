@@ -0,0 +1,207 @@
+package iterhelper
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// seqErrInt yields 0..n-1 and then 'err', if 'err' is not nil.
+func seqErrInt(n int, err error) SeqErr[int] {
+	return func(yield func(int, error) bool) {
+		for i := range n {
+			if !yield(i, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(0, err)
+		}
+	}
+}
+
+func TestTryCollect_int(t *testing.T) {
+	tests := []struct {
+		name        string
+		seqErr      SeqErr[int]
+		want        []int
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSource",
+			seqErr:      nil,
+			wantErr:     true,
+			expectedErr: ErrNilSec2,
+		},
+		{name: "Empty",
+			seqErr: seqErrInt(0, nil),
+			want:   nil,
+		},
+		{name: "NoError",
+			seqErr: seqErrInt(3, nil),
+			want:   []int{0, 1, 2},
+		},
+		{name: "Error",
+			seqErr:      seqErrInt(3, ErrTestError),
+			want:        []int{0, 1, 2},
+			wantErr:     true,
+			expectedErr: ErrTestError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TryCollect(tt.seqErr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TryCollect() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("TryCollect() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("TryCollect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForEachErr_int(t *testing.T) {
+	var acc int
+	tests := []struct {
+		name        string
+		seqErr      SeqErr[int]
+		action      func(int) error
+		want        int
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilAction",
+			seqErr:      seqErrInt(3, nil),
+			wantErr:     true,
+			expectedErr: ErrNilAction,
+		},
+		{name: "SourceError",
+			seqErr:      seqErrInt(3, ErrTestError),
+			action:      func(i int) error { acc += i; return nil },
+			want:        3,
+			wantErr:     true,
+			expectedErr: ErrTestError,
+		},
+		{name: "ActionError",
+			seqErr: seqErrInt(3, nil),
+			action: func(i int) error {
+				if i == 1 {
+					return ErrTestError
+				}
+				acc += i
+				return nil
+			},
+			wantErr:     true,
+			expectedErr: ErrTestError,
+		},
+		{name: "Regular",
+			seqErr: seqErrInt(4, nil),
+			action: func(i int) error { acc += i; return nil },
+			want:   6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc = 0
+			err := ForEachErr(context.Background(), tt.seqErr, tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ForEachErr() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("ForEachErr() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if acc != tt.want {
+				t.Errorf("ForEachErr() = %v, want %v", acc, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapErr_int_string(t *testing.T) {
+	if _, err := MapErr[int, string](seqErrInt(3, nil), nil); !errors.Is(err, ErrNilSelector) {
+		t.Errorf("MapErr() error = %v, expectedErr %v", err, ErrNilSelector)
+	}
+	got, err := MapErr(seqErrInt(4, ErrTestError), func(i int) (string, error) {
+		if i == 2 {
+			return "", strconv.ErrRange
+		}
+		return strconv.Itoa(i * 10), nil
+	})
+	if err != nil {
+		t.Fatalf("MapErr() error = %v", err)
+	}
+	var ss []string
+	var ee []error
+	for s, err := range got {
+		ss = append(ss, s)
+		ee = append(ee, err)
+	}
+	if want := []string{"0", "10", "", "30", ""}; !slices.Equal(ss, want) {
+		t.Errorf("MapErr() = %q, want %q", ss, want)
+	}
+	if ee[0] != nil || ee[1] != nil || !errors.Is(ee[2], strconv.ErrRange) || ee[3] != nil || !errors.Is(ee[4], ErrTestError) {
+		t.Errorf("MapErr() errors = %v", ee)
+	}
+}
+
+func TestFilterErr_int(t *testing.T) {
+	if _, err := FilterErr(seqErrInt(3, nil), nil); !errors.Is(err, ErrNilPredicate) {
+		t.Errorf("FilterErr() error = %v, expectedErr %v", err, ErrNilPredicate)
+	}
+	got, err := FilterErr(seqErrInt(6, ErrTestError), func(i int) (bool, error) { return i%2 == 0, nil })
+	if err != nil {
+		t.Fatalf("FilterErr() error = %v", err)
+	}
+	vv, err := TryCollect(got)
+	if want := []int{0, 2, 4}; !slices.Equal(vv, want) {
+		t.Errorf("FilterErr() = %v, want %v", vv, want)
+	}
+	if !errors.Is(err, ErrTestError) {
+		t.Errorf("FilterErr() error = %v, expectedErr %v", err, ErrTestError)
+	}
+}
+
+func TestSplitErr_int(t *testing.T) {
+	tests := []struct {
+		name    string
+		seqErr  SeqErr[int]
+		want    []int
+		wantErr error
+	}{
+		{name: "NoError",
+			seqErr: seqErrInt(3, nil),
+			want:   []int{0, 1, 2},
+		},
+		{name: "Error",
+			seqErr:  seqErrInt(2, ErrTestError),
+			want:    []int{0, 1},
+			wantErr: ErrTestError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq, errFn, err := SplitErr(tt.seqErr)
+			if err != nil {
+				t.Fatalf("SplitErr() error = %v", err)
+			}
+			got := slices.Collect(seq)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SplitErr() = %v, want %v", got, tt.want)
+			}
+			if err := errFn(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SplitErr() final error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package iterhelper
+
+import (
+	"iter"
+)
+
+// Sequence is implemented by types that provide an [iterator] over their values.
+// Some functions of the package (e.g. [EqualSequence]) recognize
+// particular implementations of Sequence (e.g. slice-backed [Sized] returned by [SliceSized] and [VarSized])
+// and take shortcuts for them.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+type Sequence[V any] interface {
+	All() iter.Seq[V]
+}
+
+// sliceBacked is implemented by [Sequence]s that may be backed by a slice.
+type sliceBacked[V any] interface {
+	backingSlice() ([]V, bool)
+}
+
+// backingSlice returns the slice backing 's', if any.
+func backingSlice[V any](s Sequence[V]) ([]V, bool) {
+	if sb, ok := s.(sliceBacked[V]); ok {
+		return sb.backingSlice()
+	}
+	return nil, false
+}
@@ -0,0 +1,114 @@
+package iterhelper
+
+import (
+	"iter"
+	"slices"
+)
+
+// Sized is a [Sequence] with a hint of the number of its values.
+// The hint is used to preallocate memory, it is not guaranteed to be exact.
+type Sized[V any] struct {
+	seq iter.Seq[V]
+	// lenHint is negative if the number of values is unknown
+	lenHint int
+	// slice is non-nil if the Sized is backed by a slice
+	slice []V
+}
+
+// NewSized returns a [Sized] over the [iterator] with the length hint 'lenHint'.
+// Negative 'lenHint' means that the number of values is unknown.
+// If 'seq' is nil, [Sized] over the empty sequence is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func NewSized[V any](seq iter.Seq[V], lenHint int) Sized[V] {
+	if seq == nil {
+		return Sized[V]{seq: Empty[V](), lenHint: 0}
+	}
+	return Sized[V]{seq: seq, lenHint: max(lenHint, -1)}
+}
+
+// SliceSized returns a [Sized] backed by the slice.
+func SliceSized[V any](s []V) Sized[V] {
+	if s == nil {
+		s = []V{}
+	}
+	return Sized[V]{seq: slices.Values(s), lenHint: len(s), slice: s}
+}
+
+// All returns an [iterator] over the values of the [Sized].
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func (s Sized[V]) All() iter.Seq[V] {
+	if s.seq == nil {
+		return Empty[V]()
+	}
+	return s.seq
+}
+
+// Len returns the hint of the number of values of the [Sized].
+// Negative result means that the number of values is unknown.
+func (s Sized[V]) Len() int {
+	if s.seq == nil {
+		return 0
+	}
+	return s.lenHint
+}
+
+func (s Sized[V]) backingSlice() ([]V, bool) {
+	return s.slice, s.slice != nil
+}
+
+// maxPrealloc is the maximum number of slice elements preallocated according to a length hint,
+// since the hint is not guaranteed to be exact.
+const maxPrealloc = 1 << 16
+
+// preallocLen returns the capacity to preallocate for 'lenHint' values,
+// each of which takes 'per' slice elements.
+func preallocLen(lenHint, per int) int {
+	if lenHint <= 0 {
+		return 0
+	}
+	// the limit is applied before the multiplication to avoid overflow
+	return min(lenHint, maxPrealloc/per) * per
+}
+
+// Sized2 is an [iterator] over pairs of values with a hint of the number of the pairs.
+// The hint is used to preallocate memory, it is not guaranteed to be exact.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+type Sized2[K, V any] struct {
+	seq2 iter.Seq2[K, V]
+	// lenHint is negative if the number of pairs is unknown
+	lenHint int
+}
+
+// NewSized2 returns a [Sized2] over the [iterator] with the length hint 'lenHint'.
+// Negative 'lenHint' means that the number of pairs is unknown.
+// If 'seq2' is nil, [Sized2] over the empty sequence is returned.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func NewSized2[K, V any](seq2 iter.Seq2[K, V], lenHint int) Sized2[K, V] {
+	if seq2 == nil {
+		return Sized2[K, V]{seq2: Empty2[K, V](), lenHint: 0}
+	}
+	return Sized2[K, V]{seq2: seq2, lenHint: max(lenHint, -1)}
+}
+
+// All returns an [iterator] over the pairs of values of the [Sized2].
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func (s Sized2[K, V]) All() iter.Seq2[K, V] {
+	if s.seq2 == nil {
+		return Empty2[K, V]()
+	}
+	return s.seq2
+}
+
+// Len returns the hint of the number of pairs of the [Sized2].
+// Negative result means that the number of pairs is unknown.
+func (s Sized2[K, V]) Len() int {
+	if s.seq2 == nil {
+		return 0
+	}
+	return s.lenHint
+}
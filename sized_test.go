@@ -0,0 +1,202 @@
+package iterhelper
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestNewSized(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       Sized[int]
+		want    []int
+		wantLen int
+	}{
+		{name: "Zero",
+			s:       Sized[int]{},
+			want:    nil,
+			wantLen: 0,
+		},
+		{name: "NilSource",
+			s:       NewSized[int](nil, 5),
+			want:    nil,
+			wantLen: 0,
+		},
+		{name: "UnknownLen",
+			s:       NewSized(intSeq(0, 3), -10),
+			want:    []int{0, 1, 2},
+			wantLen: -1,
+		},
+		{name: "Slice",
+			s:       SliceSized([]int{1, 2}),
+			want:    []int{1, 2},
+			wantLen: 2,
+		},
+		{name: "Var",
+			s:       VarSized(1, 2, 3),
+			want:    []int{1, 2, 3},
+			wantLen: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(tt.s.All()); !slices.Equal(got, tt.want) {
+				t.Errorf("All() = %v, want %v", got, tt.want)
+			}
+			if got := tt.s.Len(); got != tt.wantLen {
+				t.Errorf("Len() = %v, want %v", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestChanAllSized_int(t *testing.T) {
+	c := make(chan int, 5)
+	c <- 1
+	c <- 2
+	close(c)
+	s := ChanAllSized(c)
+	if s.Len() != 2 {
+		t.Errorf("ChanAllSized().Len() = %v, want %v", s.Len(), 2)
+	}
+	if got := CollectSized(s); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("ChanAllSized() = %v, want %v", got, []int{1, 2})
+	}
+	if s := ChanAll2Sized[int](nil); s.Len() != 0 {
+		t.Errorf("ChanAll2Sized().Len() = %v, want %v", s.Len(), 0)
+	}
+}
+
+func TestCollectSized_int(t *testing.T) {
+	if got := CollectSized(Sized[int]{}); got != nil {
+		t.Errorf("CollectSized() = %v, want %v", got, nil)
+	}
+	s := NewSized(intSeq(0, 100), 100)
+	var got []int
+	allocs := testing.AllocsPerRun(10, func() {
+		got = CollectSized(s)
+	})
+	allocsUnsized := testing.AllocsPerRun(10, func() {
+		_ = slices.Collect(s.All())
+	})
+	if allocs >= allocsUnsized {
+		t.Errorf("CollectSized() made %v allocations, want less than %v", allocs, allocsUnsized)
+	}
+	if !slices.Equal(got, slices.Collect(intSeq(0, 100))) {
+		t.Errorf("CollectSized() = %v, want %v", got, slices.Collect(intSeq(0, 100)))
+	}
+}
+
+func TestCollect2Sized(t *testing.T) {
+	s := Var2TupleSized(generichelper.NewTuple2(1, "one"), generichelper.NewTuple2(2, "two"))
+	if got, want := Collect2Sized(s), []any{1, "one", 2, "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Collect2Sized() = %v, want %v", got, want)
+	}
+	want := []generichelper.Tuple2[int, string]{{Item1: 1, Item2: "one"}, {Item1: 2, Item2: "two"}}
+	if got := Collect2TupleSized(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Collect2TupleSized() = %v, want %v", got, want)
+	}
+}
+
+func TestSized_largeHint(t *testing.T) {
+	// hints are not exact, so huge ones must not make preallocation panic
+	for _, hint := range []int{math.MaxInt / 2, math.MaxInt/2 + 1, math.MaxInt} {
+		s := NewSized(Var(1, 2, 3), hint)
+		if got, want := CollectSized(s), []int{1, 2, 3}; !slices.Equal(got, want) {
+			t.Errorf("CollectSized(hint %v) = %v, want %v", hint, got, want)
+		}
+		if got, want := StringSliceSized(s), []string{"1", "2", "3"}; !slices.Equal(got, want) {
+			t.Errorf("StringSliceSized(hint %v) = %v, want %v", hint, got, want)
+		}
+		s2 := NewSized2(sec2_int_string(2), hint)
+		if got, want := Collect2Sized(s2), []any{0, "0", 1, "1"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Collect2Sized(hint %v) = %v, want %v", hint, got, want)
+		}
+		if got, want := Collect2TupleSized(s2), Collect2Tuple(sec2_int_string(2)); !reflect.DeepEqual(got, want) {
+			t.Errorf("Collect2TupleSized(hint %v) = %v, want %v", hint, got, want)
+		}
+	}
+}
+
+func TestStringSliceSized(t *testing.T) {
+	if got, want := StringSliceSized(VarSized(1, 2)), []string{"1", "2"}; !slices.Equal(got, want) {
+		t.Errorf("StringSliceSized() = %v, want %v", got, want)
+	}
+}
+
+func TestTakeSized_SkipSized(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        Sized[int]
+		count    int
+		wantTake []int
+		wantSkip []int
+		wantLen  [2]int
+	}{
+		{name: "Slice",
+			s:        VarSized(1, 2, 3),
+			count:    2,
+			wantTake: []int{1, 2},
+			wantSkip: []int{3},
+			wantLen:  [2]int{2, 1},
+		},
+		{name: "SliceCountTooLarge",
+			s:        VarSized(1, 2, 3),
+			count:    5,
+			wantTake: []int{1, 2, 3},
+			wantSkip: nil,
+			wantLen:  [2]int{3, 0},
+		},
+		{name: "KnownLen",
+			s:        NewSized(intSeq(1, 3), 3),
+			count:    1,
+			wantTake: []int{1},
+			wantSkip: []int{2, 3},
+			wantLen:  [2]int{1, 2},
+		},
+		{name: "UnknownLen",
+			s:        NewSized(intSeq(1, 3), -1),
+			count:    1,
+			wantTake: []int{1},
+			wantSkip: []int{2, 3},
+			wantLen:  [2]int{-1, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			take, err := TakeSized(tt.s, tt.count)
+			if err != nil {
+				t.Fatalf("TakeSized() error = %v", err)
+			}
+			if got := CollectSized(take); !slices.Equal(got, tt.wantTake) || take.Len() != tt.wantLen[0] {
+				t.Errorf("TakeSized() = %v, %v, want %v, %v", got, take.Len(), tt.wantTake, tt.wantLen[0])
+			}
+			skip, err := SkipSized(tt.s, tt.count)
+			if err != nil {
+				t.Fatalf("SkipSized() error = %v", err)
+			}
+			if got := CollectSized(skip); !slices.Equal(got, tt.wantSkip) || skip.Len() != tt.wantLen[1] {
+				t.Errorf("SkipSized() = %v, %v, want %v, %v", got, skip.Len(), tt.wantSkip, tt.wantLen[1])
+			}
+		})
+	}
+	if _, err := TakeSized(VarSized(1), -1); !errors.Is(err, ErrNegativeCount) {
+		t.Errorf("TakeSized() error = %v, expectedErr %v", err, ErrNegativeCount)
+	}
+}
+
+func TestEqualSequence_Sized(t *testing.T) {
+	got, err := EqualSequence[int](VarSized(1, 2, 3), NewSized(intSeq(1, 3), -1))
+	if err != nil || !got {
+		t.Errorf("EqualSequence() = %v, %v, want %v", got, err, true)
+	}
+	got, _ = EqualSequence[int](VarSized(1, 2, 3), SliceSized([]int{1, 2}))
+	if got {
+		t.Errorf("EqualSequence() = %v, want %v", got, false)
+	}
+}
@@ -0,0 +1,131 @@
+package iterhelper
+
+import (
+	"iter"
+
+	"github.com/solsw/errorhelper"
+)
+
+// Skip returns an [iterator] over values yielded by 'seq'
+// except for 'count' first ones.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func Skip[V any](seq iter.Seq[V], count int) (iter.Seq[V], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if count < 0 {
+		return nil, errorhelper.CallerError(ErrNegativeCount)
+	}
+	return func(yield func(V) bool) {
+			i := 0
+			for v := range seq {
+				if i < count {
+					i++
+					continue
+				}
+				if !yield(v) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// Skip2 returns an [iterator] over pairs of values yielded by 'seq2'
+// except for 'count' first ones.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func Skip2[K, V any](seq2 iter.Seq2[K, V], count int) (iter.Seq2[K, V], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if count < 0 {
+		return nil, errorhelper.CallerError(ErrNegativeCount)
+	}
+	return func(yield func(K, V) bool) {
+			i := 0
+			for k, v := range seq2 {
+				if i < count {
+					i++
+					continue
+				}
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// SkipWhile returns an [iterator] over values yielded by 'seq'
+// starting from the first value that does not satisfy 'predicate'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func SkipWhile[V any](seq iter.Seq[V], predicate func(V) bool) (iter.Seq[V], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if predicate == nil {
+		return nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	return func(yield func(V) bool) {
+			skipping := true
+			for v := range seq {
+				if skipping && predicate(v) {
+					continue
+				}
+				skipping = false
+				if !yield(v) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// SkipWhile2 returns an [iterator] over pairs of values yielded by 'seq2'
+// starting from the first pair that does not satisfy 'predicate'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func SkipWhile2[K, V any](seq2 iter.Seq2[K, V], predicate func(K, V) bool) (iter.Seq2[K, V], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if predicate == nil {
+		return nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	return func(yield func(K, V) bool) {
+			skipping := true
+			for k, v := range seq2 {
+				if skipping && predicate(k, v) {
+					continue
+				}
+				skipping = false
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// SkipSized returns a [Sized] over values of 's' except for 'count' first ones.
+// The length hint of the result is derived from the length hint of 's'.
+func SkipSized[V any](s Sized[V], count int) (Sized[V], error) {
+	if count < 0 {
+		return Sized[V]{}, errorhelper.CallerError(ErrNegativeCount)
+	}
+	if slice, ok := s.backingSlice(); ok {
+		return SliceSized(slice[min(count, len(slice)):]), nil
+	}
+	seq, err := Skip(s.All(), count)
+	if err != nil {
+		return Sized[V]{}, errorhelper.CallerError(err)
+	}
+	n := s.Len()
+	if n >= 0 {
+		n = max(n-count, 0)
+	}
+	return NewSized(seq, n), nil
+}
@@ -0,0 +1,104 @@
+package iterhelper
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestSkip_int(t *testing.T) {
+	type args struct {
+		seq   iter.Seq[int]
+		count int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        iter.Seq[int]
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSource",
+			args:        args{seq: nil, count: 1},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "NegativeCount",
+			args:        args{seq: Var(1, 2, 3), count: -1},
+			wantErr:     true,
+			expectedErr: ErrNegativeCount,
+		},
+		{name: "Zero",
+			args: args{seq: Var(1, 2, 3), count: 0},
+			want: Var(1, 2, 3),
+		},
+		{name: "Less",
+			args: args{seq: Var(1, 2, 3), count: 2},
+			want: Var(3),
+		},
+		{name: "More",
+			args: args{seq: Var(1, 2, 3), count: 5},
+			want: Empty[int](),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Skip(tt.args.seq, tt.args.count)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Skip() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Skip() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			equal, _ := Equal(got, tt.want)
+			if !equal {
+				t.Errorf("Skip() = %v, want %v", StringDef(got), StringDef(tt.want))
+			}
+		})
+	}
+}
+
+func TestSkip2_int_string(t *testing.T) {
+	got, _ := Skip2(sec2_int_string(4), 2)
+	want := Var2Tuple(
+		generichelper.NewTuple2(2, "2"),
+		generichelper.NewTuple2(3, "3"),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("Skip2() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+}
+
+func TestSkipWhile_int(t *testing.T) {
+	got, err := SkipWhile(Var(1, 2, 3, 1, 2), func(i int) bool { return i < 3 })
+	if err != nil {
+		t.Fatalf("SkipWhile() error = %v", err)
+	}
+	want := Var(3, 1, 2)
+	equal, _ := Equal(got, want)
+	if !equal {
+		t.Errorf("SkipWhile() = %v, want %v", StringDef(got), StringDef(want))
+	}
+	if _, err := SkipWhile(Var(1), nil); !errors.Is(err, ErrNilPredicate) {
+		t.Errorf("SkipWhile() error = %v, expectedErr %v", err, ErrNilPredicate)
+	}
+}
+
+func TestSkipWhile2_int_string(t *testing.T) {
+	got, _ := SkipWhile2(sec2_int_string(4), func(i int, _ string) bool { return i < 2 })
+	want := Var2Tuple(
+		generichelper.NewTuple2(2, "2"),
+		generichelper.NewTuple2(3, "3"),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("SkipWhile2() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+}
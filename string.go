@@ -1,12 +1,17 @@
 package iterhelper
 
 import (
+	"bytes"
+	"cmp"
 	"fmt"
+	"io"
 	"iter"
 	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
 )
 
 // Format defines formatting parameters.
@@ -19,6 +24,56 @@ type Format struct {
 	LeftEdge, RightEdge string
 	// Value separator separates values in pair.
 	ValueSeparator string
+	// Verb (e.g. "%q", "%x", "%#v") is used to format each value with [fmt.Fprintf].
+	// If Verb is empty, values are formatted with [fmt.Fprint].
+	Verb string
+	// FormatElement, if not nil, formats each element of a sequence instead of Verb.
+	FormatElement func(any) string
+	// FormatKey and FormatValue, if not nil, format the first and the second value
+	// of each pair of a sequence of pairs respectively instead of Verb.
+	FormatKey, FormatValue func(any) string
+	// MaxElements, if positive, limits the number of elements written.
+	MaxElements int
+	// MaxLength, if positive, limits the length in bytes of the written elements
+	// (with their rims and separators). An element that does not fit is not written.
+	MaxLength int
+	// Ellipsis is written as the last element, when some elements are omitted due to the limits.
+	// If Ellipsis is empty, "..." is used.
+	Ellipsis string
+	// CountOmitted makes the rest of a sequence consumed after a limit is hit
+	// to count the omitted elements and append " (+N more)" to Ellipsis.
+	// Otherwise a sequence is not consumed beyond the first omitted element.
+	CountOmitted bool
+	// Escape, if not empty, is written within formatted values before each rune
+	// that starts Escape, a rim or a separator,
+	// so that the values can be restored by [ParseFmt] and [ParseFmt2].
+	Escape string
+}
+
+// escapeRunes returns runes that are escaped within formatted values.
+func (f Format) escapeRunes() []rune {
+	var rr []rune
+	for _, t := range []string{f.Escape, f.LeftRim, f.RightRim, f.ElementSeparator, f.ValueSeparator} {
+		if r, _ := utf8.DecodeRuneInString(t); t != "" && !slices.Contains(rr, r) {
+			rr = append(rr, r)
+		}
+	}
+	return rr
+}
+
+// escape prefixes each of 'runes' in 's' with 'escape'.
+func escape(s, escape string, runes []rune) string {
+	if !strings.ContainsFunc(s, func(r rune) bool { return slices.Contains(runes, r) }) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if slices.Contains(runes, r) {
+			b.WriteString(escape)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
 }
 
 // DefaultFormat represents default formatting parameters used by [StringDef] and [StringDef2].
@@ -33,7 +88,9 @@ var DefaultFormat = Format{
 }
 
 // StringFmt returns string representation of a [sequence] of values
-// by calling [fmt.Sprint] on each value yielded by the [iterator].
+// by formatting each value yielded by the [iterator] as specified by 'format'.
+// Element separator is written between every two adjacent elements, including empty ones,
+// so that the elements can be told apart (e.g. by [ParseFmt]).
 // If 'seq' is nil, empty string is returned.
 //
 // [sequence]: https://pkg.go.dev/iter#Seq
@@ -43,13 +100,32 @@ func StringFmt[V any](seq iter.Seq[V], format Format) string {
 		return ""
 	}
 	var b strings.Builder
-	for v := range seq {
-		if b.Len() > 0 {
-			b.WriteString(format.ElementSeparator)
-		}
-		b.WriteString(format.LeftRim + fmt.Sprint(v) + format.RightRim)
+	// strings.Builder never fails
+	_, _ = WriteFmt(&b, seq, format)
+	return b.String()
+}
+
+// WriteFmt writes string representation of a [sequence] of values to 'w'
+// element by element formatting each value yielded by the [iterator] as specified by 'format'.
+// The representation is the same as the one returned by [StringFmt].
+// The number of bytes written and the first write error, if any, are returned.
+// No further values are pulled from the [iterator] after a write error.
+//
+// [sequence]: https://pkg.go.dev/iter#Seq
+// [iterator]: https://pkg.go.dev/iter#Seq
+func WriteFmt[V any](w io.Writer, seq iter.Seq[V], format Format) (int64, error) {
+	if w == nil {
+		return 0, errorhelper.CallerError(ErrNilWriter)
+	}
+	if seq == nil {
+		return 0, errorhelper.CallerError(ErrNilSec)
 	}
-	return format.LeftEdge + b.String() + format.RightEdge
+	n, err := writeElements(w, seq, format, func(fw *fmtWriter, v V) {
+		fw.str(format.LeftRim)
+		fw.val(v, format.FormatElement, format.Verb)
+		fw.str(format.RightRim)
+	})
+	return n, errorhelper.CallerError(err)
 }
 
 // StringDef returns string representation of a [sequence] of values
@@ -64,7 +140,8 @@ func StringDef[V any](seq iter.Seq[V]) string {
 }
 
 // StringFmt2 returns string representation of a [sequence] of pairs of values
-// by calling [fmt.Sprint] on each value yielded by the [iterator]:
+// by formatting each value yielded by the [iterator] as specified by 'format'.
+// Element separator is written between every two adjacent pairs (see [StringFmt]).
 // If 'seq2' is nil, empty string is returned.
 //
 // [sequence]: https://pkg.go.dev/iter#Seq2
@@ -74,13 +151,91 @@ func StringFmt2[K, V any](seq2 iter.Seq2[K, V], format Format) string {
 		return ""
 	}
 	var b strings.Builder
-	for k, v := range seq2 {
-		if b.Len() > 0 {
-			b.WriteString(format.ElementSeparator)
+	// strings.Builder never fails
+	_, _ = WriteFmt2(&b, seq2, format)
+	return b.String()
+}
+
+// WriteFmt2 writes string representation of a [sequence] of pairs of values to 'w'
+// pair by pair formatting each value yielded by the [iterator] as specified by 'format'.
+// The representation is the same as the one returned by [StringFmt2].
+// The number of bytes written and the first write error, if any, are returned.
+// No further pairs are pulled from the [iterator] after a write error.
+//
+// [sequence]: https://pkg.go.dev/iter#Seq2
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func WriteFmt2[K, V any](w io.Writer, seq2 iter.Seq2[K, V], format Format) (int64, error) {
+	if w == nil {
+		return 0, errorhelper.CallerError(ErrNilWriter)
+	}
+	if seq2 == nil {
+		return 0, errorhelper.CallerError(ErrNilSec2)
+	}
+	n, err := writeElements(w, tupleSeq(seq2), format, func(fw *fmtWriter, t generichelper.Tuple2[K, V]) {
+		fw.str(format.LeftRim)
+		fw.val(t.Item1, format.FormatKey, format.Verb)
+		fw.str(format.ValueSeparator)
+		fw.val(t.Item2, format.FormatValue, format.Verb)
+		fw.str(format.RightRim)
+	})
+	return n, errorhelper.CallerError(err)
+}
+
+// writeElements writes elements yielded by 'seq' to 'w' with 'writeElem'
+// surrounding and separating them and applying limits as specified by 'format'.
+func writeElements[E any](w io.Writer, seq iter.Seq[E], format Format, writeElem func(*fmtWriter, E)) (int64, error) {
+	fw := fmtWriter{w: w}
+	if format.Escape != "" {
+		fw.escape, fw.runes = format.Escape, format.escapeRunes()
+	}
+	fw.str(format.LeftEdge)
+	var buf bytes.Buffer
+	written, length, omitted := 0, 0, 0
+	for e := range seq {
+		if omitted > 0 {
+			omitted++
+			continue
+		}
+		sep := generichelper.Ternary(written > 0, format.ElementSeparator, "")
+		if format.MaxElements > 0 && written == format.MaxElements {
+			omitted++
+		} else if format.MaxLength > 0 {
+			buf.Reset()
+			bw := fmtWriter{w: &buf, escape: fw.escape, runes: fw.runes}
+			bw.str(sep)
+			writeElem(&bw, e)
+			if length+buf.Len() > format.MaxLength {
+				omitted++
+			} else {
+				length += buf.Len()
+				fw.str(buf.String())
+			}
+		} else {
+			fw.str(sep)
+			writeElem(&fw, e)
+		}
+		if fw.err != nil {
+			break
+		}
+		if omitted > 0 {
+			if !format.CountOmitted {
+				break
+			}
+			continue
+		}
+		written++
+	}
+	if omitted > 0 {
+		if written > 0 {
+			fw.str(format.ElementSeparator)
+		}
+		fw.str(cmp.Or(format.Ellipsis, "..."))
+		if format.CountOmitted {
+			fw.str(fmt.Sprintf(" (+%d more)", omitted))
 		}
-		b.WriteString(format.LeftRim + fmt.Sprint(k) + format.ValueSeparator + fmt.Sprint(v) + format.RightRim)
 	}
-	return format.LeftEdge + b.String() + format.RightEdge
+	fw.str(format.RightEdge)
+	return fw.n, fw.err
 }
 
 // StringDef2 returns string representation of a [sequence] of pairs of values
@@ -94,6 +249,65 @@ func StringDef2[K, V any](seq2 iter.Seq2[K, V]) string {
 	return StringFmt2(seq2, DefaultFormat)
 }
 
+// fmtWriter counts bytes written to 'w' and stops writing after the first error.
+type fmtWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+	// escape, if not empty, is written before each of 'runes' within values
+	escape string
+	runes  []rune
+}
+
+func (fw *fmtWriter) str(s string) {
+	if fw.err != nil || s == "" {
+		return
+	}
+	n, err := io.WriteString(fw.w, s)
+	fw.n += int64(n)
+	fw.err = err
+}
+
+func (fw *fmtWriter) write(b []byte) {
+	if fw.err != nil || len(b) == 0 {
+		return
+	}
+	n, err := fw.w.Write(b)
+	fw.n += int64(n)
+	fw.err = err
+}
+
+func (fw *fmtWriter) escaped(s string) string {
+	if fw.escape == "" {
+		return s
+	}
+	return escape(s, fw.escape, fw.runes)
+}
+
+// val writes 'v' formatted by 'formatter', if it is not nil, or by 'verb', if it is not empty.
+func (fw *fmtWriter) val(v any, formatter func(any) string, verb string) {
+	if fw.err != nil {
+		return
+	}
+	if formatter != nil {
+		fw.str(fw.escaped(formatter(v)))
+		return
+	}
+	if fw.escape != "" {
+		fw.str(fw.escaped(generichelper.Ternary(verb != "", fmt.Sprintf(verb, v), fmt.Sprint(v))))
+		return
+	}
+	var n int
+	var err error
+	if verb != "" {
+		n, err = fmt.Fprintf(fw.w, verb, v)
+	} else {
+		n, err = fmt.Fprint(fw.w, v)
+	}
+	fw.n += int64(n)
+	fw.err = err
+}
+
 // StringSeq converts an [iterator] to an [iterator] over strings
 // by calling [fmt.Sprint] on each value yielded by the [iterator].
 //
@@ -122,3 +336,20 @@ func StringSlice[V any](seq iter.Seq[V]) ([]string, error) {
 	}
 	return slices.Collect(seqString), nil
 }
+
+// StringSliceSized returns a sequence of values yielded by the [Sized] as a slice of strings.
+// The slice is preallocated according to the length hint of 's' (up to a limit).
+// If 's' yields no values, nil is returned.
+func StringSliceSized[V any](s Sized[V]) []string {
+	var r []string
+	if n := preallocLen(s.Len(), 1); n > 0 {
+		r = make([]string, 0, n)
+	}
+	for v := range s.All() {
+		r = append(r, fmt.Sprint(v))
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
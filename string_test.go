@@ -1,9 +1,11 @@
 package iterhelper
 
 import (
+	"errors"
 	"fmt"
 	"iter"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/solsw/generichelper"
@@ -244,3 +246,278 @@ func TestStringDef2_int_string(t *testing.T) {
 		})
 	}
 }
+
+// failingWriter fails after 'limit' bytes are written.
+type failingWriter struct {
+	limit int
+	b     strings.Builder
+}
+
+func (fw *failingWriter) Write(p []byte) (int, error) {
+	if fw.b.Len()+len(p) > fw.limit {
+		n := fw.limit - fw.b.Len()
+		fw.b.Write(p[:n])
+		return n, ErrTestError
+	}
+	return fw.b.Write(p)
+}
+
+func TestWriteFmt_int(t *testing.T) {
+	format := Format{
+		LeftRim:          "<",
+		RightRim:         ">",
+		ElementSeparator: "-",
+		LeftEdge:         "[",
+		RightEdge:        "]",
+	}
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+	}{
+		{name: "Empty", seq: Empty[int]()},
+		{name: "One", seq: Var(1)},
+		{name: "Many", seq: Var(1, 22, 333)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			n, err := WriteFmt(&b, tt.seq, format)
+			if err != nil {
+				t.Fatalf("WriteFmt() error = %v", err)
+			}
+			want := StringFmt(tt.seq, format)
+			if got := b.String(); got != want || n != int64(len(want)) {
+				t.Errorf("WriteFmt() = %q, %v, want %q, %v", got, n, want, len(want))
+			}
+		})
+	}
+	if _, err := WriteFmt[int](&strings.Builder{}, nil, format); !errors.Is(err, ErrNilSec) {
+		t.Errorf("WriteFmt() error = %v, expectedErr %v", err, ErrNilSec)
+	}
+}
+
+func TestWriteFmt_writeError(t *testing.T) {
+	pulled := 0
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	w := &failingWriter{limit: 5}
+	n, err := WriteFmt(w, seq, DefaultFormat)
+	if !errors.Is(err, ErrTestError) {
+		t.Errorf("WriteFmt() error = %v, expectedErr %v", err, ErrTestError)
+	}
+	if n != 5 || w.b.String() != "[0 1 " {
+		t.Errorf("WriteFmt() = %q, %v, want %q, %v", w.b.String(), n, "[0 1 ", 5)
+	}
+	// the value whose write failed is the last one pulled
+	if pulled != 3 {
+		t.Errorf("WriteFmt() pulled %v values, want %v", pulled, 3)
+	}
+}
+
+func TestStringDef_emptyElements(t *testing.T) {
+	// the separator is written between empty elements too, leading ones included,
+	// so that ParseFmt can tell the elements apart
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "Leading", got: StringDef(Var("", "a")), want: "[ a]"},
+		{name: "Trailing", got: StringDef(Var("a", "")), want: "[a ]"},
+		{name: "AllEmpty", got: StringDef(Var("", "", "")), want: "[  ]"},
+		{name: "Pairs", got: StringFmt2(Var2Tuple(generichelper.NewTuple2("", ""), generichelper.NewTuple2("a", "b")),
+			Format{ElementSeparator: ",", LeftEdge: "[", RightEdge: "]"}), want: "[,ab]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("StringDef() = %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFmt2_int_string(t *testing.T) {
+	var b strings.Builder
+	n, err := WriteFmt2(&b, sec2_int_string(3), DefaultFormat)
+	if err != nil {
+		t.Fatalf("WriteFmt2() error = %v", err)
+	}
+	want := "[0:0 1:1 2:2]"
+	if got := b.String(); got != want || n != int64(len(want)) {
+		t.Errorf("WriteFmt2() = %q, %v, want %q, %v", got, n, want, len(want))
+	}
+	if got := StringFmt2(Var2Tuple(generichelper.NewTuple2("", ""), generichelper.NewTuple2("", "")),
+		Format{ElementSeparator: ","}); got != "," {
+		t.Errorf("StringFmt2() = %q, want %q", got, ",")
+	}
+}
+
+func TestStringFmt_formatters(t *testing.T) {
+	truncate := func(v any) string {
+		s := fmt.Sprint(v)
+		if len(s) > 3 {
+			return s[:3] + "…"
+		}
+		return s
+	}
+	tests := []struct {
+		name   string
+		seq    iter.Seq[any]
+		format Format
+		want   string
+	}{
+		{name: "QuotedVerb",
+			seq:    Var[any]("a", "b c"),
+			format: Format{ElementSeparator: ",", LeftEdge: "[", RightEdge: "]", Verb: "%q"},
+			want:   `["a","b c"]`,
+		},
+		{name: "HexVerb",
+			seq:    Var[any]([]byte{1, 255}, 16),
+			format: Format{ElementSeparator: " ", Verb: "%x"},
+			want:   "01ff 10",
+		},
+		{name: "ElementFormatterOverridesVerb",
+			seq:    Var[any]("abcdef", "ab"),
+			format: Format{ElementSeparator: " ", Verb: "%q", FormatElement: truncate},
+			want:   "abc… ab",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringFmt(tt.seq, tt.format); got != tt.want {
+				t.Errorf("StringFmt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringFmt2_formatters(t *testing.T) {
+	format := DefaultFormat
+	format.Verb = "%#v"
+	format.FormatKey = func(v any) string { return fmt.Sprintf("k%v", v) }
+	if got, want := StringFmt2(sec2_int_string(2), format), `[k0:"0" k1:"1"]`; got != want {
+		t.Errorf("StringFmt2() = %v, want %v", got, want)
+	}
+	format.FormatValue = func(v any) string { return strings.Repeat("*", len(v.(string))) }
+	if got, want := StringFmt2(sec2_int_string(2), format), `[k0:* k1:*]`; got != want {
+		t.Errorf("StringFmt2() = %v, want %v", got, want)
+	}
+}
+
+func TestStringFmt_escape(t *testing.T) {
+	tests := []struct {
+		name   string
+		seq    iter.Seq[any]
+		format Format
+		want   string
+	}{
+		{name: "NoEscape",
+			seq:    Var[any]("a b", "c"),
+			format: DefaultFormat,
+			want:   "[a b c]",
+		},
+		{name: "Separator",
+			seq:    Var[any]("a b", "c"),
+			format: Format{ElementSeparator: " ", LeftEdge: "[", RightEdge: "]", Escape: `\`},
+			want:   `[a\ b c]`,
+		},
+		{name: "EscapeAndRims",
+			seq:    Var[any](`a\b`, `"c"`),
+			format: Format{LeftRim: `"`, RightRim: `"`, ElementSeparator: ",", Escape: `\`},
+			want:   `"a\\b","\"c\""`,
+		},
+		{name: "Verb",
+			seq:    Var[any]("a,b", "c"),
+			format: Format{ElementSeparator: ",", Verb: "%q", Escape: "!"},
+			want:   `"a!,b","c"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringFmt(tt.seq, tt.format); got != tt.want {
+				t.Errorf("StringFmt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringFmt_limits(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		format     Format
+		want       string
+		wantPulled int
+	}{
+		{name: "MaxElementsNotHit",
+			count:      3,
+			format:     Format{ElementSeparator: " ", MaxElements: 3},
+			want:       "0 1 2",
+			wantPulled: 3,
+		},
+		{name: "MaxElements",
+			count:      1000,
+			format:     Format{ElementSeparator: " ", LeftEdge: "[", RightEdge: "]", MaxElements: 3},
+			want:       "[0 1 2 ...]",
+			wantPulled: 4,
+		},
+		{name: "MaxElementsCountOmitted",
+			count:      1000,
+			format:     Format{ElementSeparator: " ", MaxElements: 3, Ellipsis: "…", CountOmitted: true},
+			want:       "0 1 2 … (+997 more)",
+			wantPulled: 1000,
+		},
+		{name: "MaxLength",
+			count:      20,
+			format:     Format{LeftRim: "<", RightRim: ">", ElementSeparator: ",", MaxLength: 12},
+			want:       "<0>,<1>,<2>,...",
+			wantPulled: 4,
+		},
+		{name: "MaxLengthFirstDoesNotFit",
+			count:      20,
+			format:     Format{LeftRim: "<", RightRim: ">", ElementSeparator: ",", MaxLength: 2},
+			want:       "...",
+			wantPulled: 1,
+		},
+		{name: "MaxLengthCountOmitted",
+			count:      12,
+			format:     Format{ElementSeparator: ",", MaxLength: 20, CountOmitted: true},
+			want:       "0,1,2,3,4,5,6,7,8,9,... (+2 more)",
+			wantPulled: 12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pulled := 0
+			seq := func(yield func(int) bool) {
+				for i := range tt.count {
+					pulled++
+					if !yield(i) {
+						return
+					}
+				}
+			}
+			if got := StringFmt(seq, tt.format); got != tt.want {
+				t.Errorf("StringFmt() = %v, want %v", got, tt.want)
+			}
+			if pulled != tt.wantPulled {
+				t.Errorf("StringFmt() pulled %v values, want %v", pulled, tt.wantPulled)
+			}
+		})
+	}
+}
+
+func TestStringFmt2_limits(t *testing.T) {
+	format := DefaultFormat
+	format.MaxElements = 2
+	if got, want := StringFmt2(sec2_int_string(5), format), "[0:0 1:1 ...]"; got != want {
+		t.Errorf("StringFmt2() = %v, want %v", got, want)
+	}
+}
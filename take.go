@@ -0,0 +1,127 @@
+package iterhelper
+
+import (
+	"iter"
+
+	"github.com/solsw/errorhelper"
+)
+
+// Take returns an [iterator] over at most 'count' first values yielded by 'seq'.
+// 'seq' is not pulled beyond the 'count'-th value.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func Take[V any](seq iter.Seq[V], count int) (iter.Seq[V], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if count < 0 {
+		return nil, errorhelper.CallerError(ErrNegativeCount)
+	}
+	if count == 0 {
+		return Empty[V](), nil
+	}
+	return func(yield func(V) bool) {
+			i := 0
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+				i++
+				if i == count {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// Take2 returns an [iterator] over at most 'count' first pairs of values yielded by 'seq2'.
+// 'seq2' is not pulled beyond the 'count'-th pair.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func Take2[K, V any](seq2 iter.Seq2[K, V], count int) (iter.Seq2[K, V], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if count < 0 {
+		return nil, errorhelper.CallerError(ErrNegativeCount)
+	}
+	if count == 0 {
+		return Empty2[K, V](), nil
+	}
+	return func(yield func(K, V) bool) {
+			i := 0
+			for k, v := range seq2 {
+				if !yield(k, v) {
+					return
+				}
+				i++
+				if i == count {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// TakeWhile returns an [iterator] over values yielded by 'seq'
+// as long as 'predicate' is satisfied.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func TakeWhile[V any](seq iter.Seq[V], predicate func(V) bool) (iter.Seq[V], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if predicate == nil {
+		return nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	return func(yield func(V) bool) {
+			for v := range seq {
+				if !predicate(v) || !yield(v) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// TakeWhile2 returns an [iterator] over pairs of values yielded by 'seq2'
+// as long as 'predicate' is satisfied.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func TakeWhile2[K, V any](seq2 iter.Seq2[K, V], predicate func(K, V) bool) (iter.Seq2[K, V], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if predicate == nil {
+		return nil, errorhelper.CallerError(ErrNilPredicate)
+	}
+	return func(yield func(K, V) bool) {
+			for k, v := range seq2 {
+				if !predicate(k, v) || !yield(k, v) {
+					return
+				}
+			}
+		},
+		nil
+}
+
+// TakeSized returns a [Sized] over at most 'count' first values of 's'.
+// The length hint of the result is derived from the length hint of 's'.
+func TakeSized[V any](s Sized[V], count int) (Sized[V], error) {
+	if count < 0 {
+		return Sized[V]{}, errorhelper.CallerError(ErrNegativeCount)
+	}
+	if slice, ok := s.backingSlice(); ok {
+		return SliceSized(slice[:min(count, len(slice))]), nil
+	}
+	seq, err := Take(s.All(), count)
+	if err != nil {
+		return Sized[V]{}, errorhelper.CallerError(err)
+	}
+	n := s.Len()
+	if n >= 0 {
+		n = min(n, count)
+	}
+	return NewSized(seq, n), nil
+}
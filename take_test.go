@@ -0,0 +1,122 @@
+package iterhelper
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestTake_int(t *testing.T) {
+	type args struct {
+		seq   iter.Seq[int]
+		count int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        iter.Seq[int]
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSource",
+			args:        args{seq: nil, count: 1},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "NegativeCount",
+			args:        args{seq: Var(1, 2, 3), count: -1},
+			wantErr:     true,
+			expectedErr: ErrNegativeCount,
+		},
+		{name: "Zero",
+			args: args{seq: Var(1, 2, 3), count: 0},
+			want: Empty[int](),
+		},
+		{name: "Less",
+			args: args{seq: Var(1, 2, 3), count: 2},
+			want: Var(1, 2),
+		},
+		{name: "More",
+			args: args{seq: Var(1, 2, 3), count: 5},
+			want: Var(1, 2, 3),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Take(tt.args.seq, tt.args.count)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Take() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Take() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			equal, _ := Equal(got, tt.want)
+			if !equal {
+				t.Errorf("Take() = %v, want %v", StringDef(got), StringDef(tt.want))
+			}
+		})
+	}
+}
+
+func TestTake_doesNotOverpull(t *testing.T) {
+	pulled := 0
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	got, _ := Take(seq, 3)
+	for range got {
+	}
+	if pulled != 3 {
+		t.Errorf("Take() pulled %v values, want %v", pulled, 3)
+	}
+}
+
+func TestTake2_int_string(t *testing.T) {
+	got, _ := Take2(sec2_int_string(5), 2)
+	want := Var2Tuple(
+		generichelper.NewTuple2(0, "0"),
+		generichelper.NewTuple2(1, "1"),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("Take2() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+}
+
+func TestTakeWhile_int(t *testing.T) {
+	got, err := TakeWhile(Var(1, 2, 3, 1, 2), func(i int) bool { return i < 3 })
+	if err != nil {
+		t.Fatalf("TakeWhile() error = %v", err)
+	}
+	want := Var(1, 2)
+	equal, _ := Equal(got, want)
+	if !equal {
+		t.Errorf("TakeWhile() = %v, want %v", StringDef(got), StringDef(want))
+	}
+	if _, err := TakeWhile(Var(1), nil); !errors.Is(err, ErrNilPredicate) {
+		t.Errorf("TakeWhile() error = %v, expectedErr %v", err, ErrNilPredicate)
+	}
+}
+
+func TestTakeWhile2_int_string(t *testing.T) {
+	got, _ := TakeWhile2(sec2_int_string(5), func(i int, _ string) bool { return i < 2 })
+	want := Var2Tuple(
+		generichelper.NewTuple2(0, "0"),
+		generichelper.NewTuple2(1, "1"),
+	)
+	equal, _ := Equal2(got, want)
+	if !equal {
+		t.Errorf("TakeWhile2() = %v, want %v", StringDef2(got), StringDef2(want))
+	}
+}
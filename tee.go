@@ -0,0 +1,147 @@
+package iterhelper
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// TeePolicy defines the behavior of [Tee] when a consumer falls behind.
+type TeePolicy int
+
+const (
+	// TeeBlock makes the source wait until the slow consumer catches up.
+	TeeBlock TeePolicy = iota
+	// TeeDrop drops values the slow consumer has no room for.
+	TeeDrop
+	// TeeError ends the slow consumer's sequence with [ErrSlowConsumer].
+	TeeError
+)
+
+type teeConsumer[V any] struct {
+	c        chan V
+	done     chan struct{}
+	doneOnce sync.Once
+	// err is set before 'c' is closed
+	err     error
+	stopped bool
+}
+
+// Tee returns 'n' [SeqErr]s, each of which yields all the values yielded by the [iterator] 'seq'.
+// The returned sequences are intended to be consumed concurrently, each of them only once.
+// 'seq' is ranged over in a separate goroutine started when the first consumer starts ranging.
+// Each consumer may lag behind the source by at most 'bufSize' values,
+// after that 'policy' is applied to the consumer.
+// A consumer that stops ranging is no longer fed,
+// 'seq' is stopped when all the consumers have stopped.
+// If 'ctx' is canceled, each unfinished sequence ends with the zero value and 'ctx' error.
+// With [TeeBlock] policy every returned sequence must be ranged over (or 'ctx' canceled),
+// otherwise the source blocks forever.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func Tee[V any](ctx context.Context, seq iter.Seq[V], n, bufSize int, policy TeePolicy) ([]SeqErr[V], error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if n <= 0 {
+		return nil, errorhelper.CallerError(ErrNonPositiveCount)
+	}
+	if bufSize < 0 {
+		return nil, errorhelper.CallerError(ErrNegativeCount)
+	}
+	if policy < TeeBlock || policy > TeeError {
+		return nil, errorhelper.CallerError(ErrUnknownPolicy)
+	}
+	cc := make([]*teeConsumer[V], n)
+	for i := range cc {
+		cc[i] = &teeConsumer[V]{c: make(chan V, bufSize), done: make(chan struct{})}
+	}
+	var startOnce sync.Once
+	start := func() {
+		go teeProduce(ctx, seq, cc, policy)
+	}
+	r := make([]SeqErr[V], n)
+	for i, tc := range cc {
+		r[i] = func(yield func(V, error) bool) {
+			startOnce.Do(start)
+			defer tc.doneOnce.Do(func() { close(tc.done) })
+			for {
+				select {
+				case <-ctx.Done():
+					// the source may be blocked and unable to notice 'ctx' cancellation
+					yield(generichelper.ZeroValue[V](), errorhelper.CallerError(ctx.Err()))
+					return
+				case v, ok := <-tc.c:
+					if !ok {
+						if tc.err != nil {
+							yield(generichelper.ZeroValue[V](), errorhelper.CallerError(tc.err))
+						}
+						return
+					}
+					if !yield(v, nil) {
+						return
+					}
+				}
+			}
+		}
+	}
+	return r, nil
+}
+
+// teeProduce feeds the consumers 'cc' with the values yielded by 'seq'.
+func teeProduce[V any](ctx context.Context, seq iter.Seq[V], cc []*teeConsumer[V], policy TeePolicy) {
+	active := len(cc)
+	stop := func(tc *teeConsumer[V], err error) {
+		tc.err = err
+		tc.stopped = true
+		close(tc.c)
+		active--
+	}
+	defer func() {
+		for _, tc := range cc {
+			if !tc.stopped {
+				stop(tc, ctx.Err())
+			}
+		}
+	}()
+	for v := range seq {
+		for _, tc := range cc {
+			if tc.stopped {
+				continue
+			}
+			select {
+			case <-tc.done:
+				stop(tc, nil)
+				continue
+			default:
+			}
+			switch policy {
+			case TeeBlock:
+				select {
+				case <-ctx.Done():
+					return
+				case <-tc.done:
+					stop(tc, nil)
+				case tc.c <- v:
+				}
+			case TeeDrop:
+				select {
+				case tc.c <- v:
+				default:
+				}
+			case TeeError:
+				select {
+				case tc.c <- v:
+				default:
+					stop(tc, ErrSlowConsumer)
+				}
+			}
+		}
+		if active == 0 || ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package iterhelper
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestTee_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		n, bufSize  int
+		policy      TeePolicy
+		expectedErr error
+	}{
+		{name: "NonPositiveCount", n: 0, bufSize: 1, policy: TeeBlock, expectedErr: ErrNonPositiveCount},
+		{name: "NegativeBufSize", n: 1, bufSize: -1, policy: TeeBlock, expectedErr: ErrNegativeCount},
+		{name: "UnknownPolicy", n: 1, bufSize: 1, policy: TeePolicy(42), expectedErr: ErrUnknownPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Tee(context.Background(), Var(1), tt.n, tt.bufSize, tt.policy)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Tee() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestTee_block(t *testing.T) {
+	ss, _ := Tee(context.Background(), intSeq(0, 100), 3, 0, TeeBlock)
+	var wg sync.WaitGroup
+	rr := make([][]int, len(ss))
+	for i, s := range ss {
+		wg.Go(func() {
+			rr[i], _ = TryCollect(s)
+		})
+	}
+	wg.Wait()
+	want := slices.Collect(intSeq(0, 100))
+	for i, r := range rr {
+		if !slices.Equal(r, want) {
+			t.Errorf("Tee() consumer %d = %v, want %v", i, r, want)
+		}
+	}
+}
+
+// teeLagging runs Tee with two consumers over 0..9, where the first consumer keeps pace with the source
+// and the second one receives 0 and then waits until the source is exhausted.
+func teeLagging(t *testing.T, bufSize int, policy TeePolicy) ([][]int, []error) {
+	ack0, ack1, sourceDone := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	source := func(yield func(int) bool) {
+		defer close(sourceDone)
+		for i := range 10 {
+			if !yield(i) {
+				return
+			}
+			<-ack0
+			if i == 0 {
+				<-ack1
+			}
+		}
+	}
+	ss, err := Tee(context.Background(), source, 2, bufSize, policy)
+	if err != nil {
+		t.Fatalf("Tee() error = %v", err)
+	}
+	rr := make([][]int, 2)
+	ee := make([]error, 2)
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for v, err := range ss[0] {
+			if err != nil {
+				ee[0] = err
+				break
+			}
+			rr[0] = append(rr[0], v)
+			ack0 <- struct{}{}
+		}
+	})
+	wg.Go(func() {
+		for v, err := range ss[1] {
+			if err != nil {
+				ee[1] = err
+				break
+			}
+			rr[1] = append(rr[1], v)
+			if v == 0 {
+				ack1 <- struct{}{}
+				<-sourceDone
+			}
+		}
+	})
+	wg.Wait()
+	return rr, ee
+}
+
+func TestTee_drop(t *testing.T) {
+	rr, ee := teeLagging(t, 3, TeeDrop)
+	if !slices.Equal(rr[0], slices.Collect(intSeq(0, 10))) || ee[0] != nil {
+		t.Errorf("Tee() consumer 0 = %v, %v", rr[0], ee[0])
+	}
+	if !slices.Equal(rr[1], []int{0, 1, 2, 3}) || ee[1] != nil {
+		t.Errorf("Tee() consumer 1 = %v, %v, want %v", rr[1], ee[1], []int{0, 1, 2, 3})
+	}
+}
+
+func TestTee_error(t *testing.T) {
+	rr, ee := teeLagging(t, 3, TeeError)
+	if !slices.Equal(rr[0], slices.Collect(intSeq(0, 10))) || ee[0] != nil {
+		t.Errorf("Tee() consumer 0 = %v, %v", rr[0], ee[0])
+	}
+	if !slices.Equal(rr[1], []int{0, 1, 2, 3}) || !errors.Is(ee[1], ErrSlowConsumer) {
+		t.Errorf("Tee() consumer 1 = %v, %v, want %v, %v", rr[1], ee[1], []int{0, 1, 2, 3}, ErrSlowConsumer)
+	}
+}
+
+func TestTee_break(t *testing.T) {
+	ss, _ := Tee(context.Background(), intSeq(0, 10), 2, 0, TeeBlock)
+	rr := make([][]int, 2)
+	var wg sync.WaitGroup
+	for i, s := range ss {
+		wg.Go(func() {
+			for v := range s {
+				rr[i] = append(rr[i], v)
+				if i == 0 && len(rr[i]) == 2 {
+					break
+				}
+			}
+		})
+	}
+	wg.Wait()
+	if !slices.Equal(rr[0], []int{0, 1}) {
+		t.Errorf("Tee() consumer 0 = %v, want %v", rr[0], []int{0, 1})
+	}
+	if !slices.Equal(rr[1], slices.Collect(intSeq(0, 10))) {
+		t.Errorf("Tee() consumer 1 = %v, want %v", rr[1], slices.Collect(intSeq(0, 10)))
+	}
+}
+
+func TestTee_allBreak(t *testing.T) {
+	stopped := make(chan struct{})
+	infinite := func(yield func(int) bool) {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	ss, _ := Tee(context.Background(), infinite, 2, 1, TeeDrop)
+	for range ss[0] {
+		break
+	}
+	for range ss[1] {
+		break
+	}
+	// the source must be stopped
+	<-stopped
+}
+
+func TestTee_blockedSource(t *testing.T) {
+	for _, policy := range []TeePolicy{TeeBlock, TeeDrop, TeeError} {
+		seq, release := idleSource(1)
+		ctx, cancel := context.WithCancel(context.Background())
+		tt, _ := Tee(ctx, seq, 2, 1, policy)
+		var wg sync.WaitGroup
+		errs := make([]error, len(tt))
+		received := make(chan struct{}, len(tt))
+		for i, seqErr := range tt {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for v, err := range seqErr {
+					if err != nil {
+						errs[i] = err
+						continue
+					}
+					if v == 1 {
+						received <- struct{}{}
+					}
+				}
+			}()
+		}
+		for range tt {
+			<-received
+		}
+		cancel()
+		finishes(t, "Tee", wg.Wait)
+		for i, err := range errs {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Tee(policy %v) consumer %v error = %v, expectedErr %v", policy, i, err, context.Canceled)
+			}
+		}
+		release()
+	}
+}
@@ -0,0 +1,68 @@
+package iterhelper
+
+import (
+	"context"
+	"iter"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// ToChan returns a [channel] with 'bufSize' capacity that receives values yielded by the [iterator].
+// The [iterator] is ranged over in a separate goroutine.
+// The [channel] is closed when the sequence ends or 'ctx' is canceled.
+// A consumer that stops receiving before the [channel] is closed must cancel 'ctx'
+// to let the goroutine finish.
+//
+// [channel]: https://go.dev/ref/spec#Channel_types
+// [iterator]: https://pkg.go.dev/iter#Seq
+func ToChan[E any](ctx context.Context, seq iter.Seq[E], bufSize int) (<-chan E, error) {
+	if seq == nil {
+		return nil, errorhelper.CallerError(ErrNilSec)
+	}
+	if bufSize < 0 {
+		return nil, errorhelper.CallerError(ErrNegativeCount)
+	}
+	c := make(chan E, bufSize)
+	go func() {
+		defer close(c)
+		for e := range seq {
+			select {
+			case <-ctx.Done():
+				return
+			case c <- e:
+			}
+		}
+	}()
+	return c, nil
+}
+
+// ToChan2 returns a [channel] with 'bufSize' capacity that receives tuples of pairs of values
+// yielded by the [iterator].
+// The [iterator] is ranged over in a separate goroutine.
+// The [channel] is closed when the sequence ends or 'ctx' is canceled.
+// A consumer that stops receiving before the [channel] is closed must cancel 'ctx'
+// to let the goroutine finish.
+//
+// [channel]: https://go.dev/ref/spec#Channel_types
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func ToChan2[K, V any](ctx context.Context, seq2 iter.Seq2[K, V], bufSize int) (<-chan generichelper.Tuple2[K, V], error) {
+	if seq2 == nil {
+		return nil, errorhelper.CallerError(ErrNilSec2)
+	}
+	if bufSize < 0 {
+		return nil, errorhelper.CallerError(ErrNegativeCount)
+	}
+	c := make(chan generichelper.Tuple2[K, V], bufSize)
+	go func() {
+		defer close(c)
+		for k, v := range seq2 {
+			select {
+			case <-ctx.Done():
+				return
+			case c <- generichelper.NewTuple2(k, v):
+			}
+		}
+	}()
+	return c, nil
+}
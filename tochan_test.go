@@ -0,0 +1,102 @@
+package iterhelper
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestToChan_int(t *testing.T) {
+	type args struct {
+		seq     iter.Seq[int]
+		bufSize int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        []int
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilSource",
+			args:        args{seq: nil, bufSize: 0},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "NegativeBufSize",
+			args:        args{seq: Var(1), bufSize: -1},
+			wantErr:     true,
+			expectedErr: ErrNegativeCount,
+		},
+		{name: "Empty",
+			args: args{seq: Empty[int](), bufSize: 0},
+			want: nil,
+		},
+		{name: "Unbuffered",
+			args: args{seq: Var(1, 2, 3), bufSize: 0},
+			want: []int{1, 2, 3},
+		},
+		{name: "Buffered",
+			args: args{seq: intSeq(0, 5), bufSize: 2},
+			want: []int{0, 1, 2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToChan(context.Background(), tt.args.seq, tt.args.bufSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToChan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("ToChan() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if rr := slices.Collect(ChanAll(got)); !slices.Equal(rr, tt.want) {
+				t.Errorf("ToChan() = %v, want %v", rr, tt.want)
+			}
+		})
+	}
+}
+
+func TestToChan_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	seq := func(yield func(int) bool) {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	c, _ := ToChan(ctx, seq, 0)
+	<-c
+	cancel()
+	// the producer must stop and close the channel
+	for range c {
+	}
+	<-stopped
+}
+
+func TestToChan2_int_string(t *testing.T) {
+	c, err := ToChan2(context.Background(), sec2_int_string(3), 1)
+	if err != nil {
+		t.Fatalf("ToChan2() error = %v", err)
+	}
+	got := slices.Collect(ChanAll(c))
+	want := []generichelper.Tuple2[int, string]{
+		generichelper.NewTuple2(0, "0"),
+		generichelper.NewTuple2(1, "1"),
+		generichelper.NewTuple2(2, "2"),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ToChan2() = %v, want %v", got, want)
+	}
+}
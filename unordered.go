@@ -0,0 +1,196 @@
+package iterhelper
+
+import (
+	"iter"
+
+	"github.com/solsw/errorhelper"
+	"github.com/solsw/generichelper"
+)
+
+// SetDiff describes the difference between two sequences compared regardless of the order.
+type SetDiff[V any] struct {
+	// Missing contains elements of the first sequence that are absent in the second one,
+	// in the order of their appearance.
+	Missing []V
+	// Extra contains elements of the second sequence that are absent in the first one,
+	// in the order of their appearance.
+	Extra []V
+}
+
+// Empty reports whether there is no difference.
+func (d SetDiff[V]) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0
+}
+
+// SetEqual determines whether two [iterator]s yield the same sets of values,
+// i.e. regardless of the order and the number of occurrences of values.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func SetEqual[V comparable](first, second iter.Seq[V]) (bool, SetDiff[V], error) {
+	if first == nil || second == nil {
+		return false, SetDiff[V]{}, errorhelper.CallerError(ErrNilSec)
+	}
+	d := unorderedDiff(first, second, identity[V], true)
+	return d.Empty(), d, nil
+}
+
+// SetEqualBy determines whether two [iterator]s yield the same sets of values,
+// i.e. regardless of the order and the number of occurrences of values.
+// Values are compared by the keys returned by 'key'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func SetEqualBy[V any, K comparable](first, second iter.Seq[V], key func(V) K) (bool, SetDiff[V], error) {
+	if first == nil || second == nil {
+		return false, SetDiff[V]{}, errorhelper.CallerError(ErrNilSec)
+	}
+	if key == nil {
+		return false, SetDiff[V]{}, errorhelper.CallerError(ErrNilSelector)
+	}
+	d := unorderedDiff(first, second, key, true)
+	return d.Empty(), d, nil
+}
+
+// MultisetEqual determines whether two [iterator]s yield the same multisets of values,
+// i.e. regardless of the order of values, but with the same numbers of occurrences.
+// Each surplus occurrence of a value is reported in the difference.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func MultisetEqual[V comparable](first, second iter.Seq[V]) (bool, SetDiff[V], error) {
+	if first == nil || second == nil {
+		return false, SetDiff[V]{}, errorhelper.CallerError(ErrNilSec)
+	}
+	d := unorderedDiff(first, second, identity[V], false)
+	return d.Empty(), d, nil
+}
+
+// MultisetEqualBy determines whether two [iterator]s yield the same multisets of values,
+// i.e. regardless of the order of values, but with the same numbers of occurrences.
+// Values are compared by the keys returned by 'key'.
+// Each surplus occurrence of a value is reported in the difference.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq
+func MultisetEqualBy[V any, K comparable](first, second iter.Seq[V], key func(V) K) (bool, SetDiff[V], error) {
+	if first == nil || second == nil {
+		return false, SetDiff[V]{}, errorhelper.CallerError(ErrNilSec)
+	}
+	if key == nil {
+		return false, SetDiff[V]{}, errorhelper.CallerError(ErrNilSelector)
+	}
+	d := unorderedDiff(first, second, key, false)
+	return d.Empty(), d, nil
+}
+
+// SetEqual2 determines whether two [iterator]s yield the same sets of pairs of values,
+// i.e. regardless of the order and the number of occurrences of pairs.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func SetEqual2[K, V comparable](first, second iter.Seq2[K, V]) (bool, SetDiff[generichelper.Tuple2[K, V]], error) {
+	if first == nil || second == nil {
+		return false, SetDiff[generichelper.Tuple2[K, V]]{}, errorhelper.CallerError(ErrNilSec2)
+	}
+	d := unorderedDiff(tupleSeq(first), tupleSeq(second), identity[generichelper.Tuple2[K, V]], true)
+	return d.Empty(), d, nil
+}
+
+// SetEqualBy2 determines whether two [iterator]s yield the same sets of pairs of values,
+// i.e. regardless of the order and the number of occurrences of pairs.
+// Pairs are compared by the keys returned by 'key'.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func SetEqualBy2[K, V any, G comparable](first, second iter.Seq2[K, V],
+	key func(K, V) G) (bool, SetDiff[generichelper.Tuple2[K, V]], error) {
+	if first == nil || second == nil {
+		return false, SetDiff[generichelper.Tuple2[K, V]]{}, errorhelper.CallerError(ErrNilSec2)
+	}
+	if key == nil {
+		return false, SetDiff[generichelper.Tuple2[K, V]]{}, errorhelper.CallerError(ErrNilSelector)
+	}
+	d := unorderedDiff(tupleSeq(first), tupleSeq(second), tupleKey(key), true)
+	return d.Empty(), d, nil
+}
+
+// MultisetEqual2 determines whether two [iterator]s yield the same multisets of pairs of values,
+// i.e. regardless of the order of pairs, but with the same numbers of occurrences.
+// Each surplus occurrence of a pair is reported in the difference.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func MultisetEqual2[K, V comparable](first, second iter.Seq2[K, V]) (bool, SetDiff[generichelper.Tuple2[K, V]], error) {
+	if first == nil || second == nil {
+		return false, SetDiff[generichelper.Tuple2[K, V]]{}, errorhelper.CallerError(ErrNilSec2)
+	}
+	d := unorderedDiff(tupleSeq(first), tupleSeq(second), identity[generichelper.Tuple2[K, V]], false)
+	return d.Empty(), d, nil
+}
+
+// MultisetEqualBy2 determines whether two [iterator]s yield the same multisets of pairs of values,
+// i.e. regardless of the order of pairs, but with the same numbers of occurrences.
+// Pairs are compared by the keys returned by 'key'.
+// Each surplus occurrence of a pair is reported in the difference.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func MultisetEqualBy2[K, V any, G comparable](first, second iter.Seq2[K, V],
+	key func(K, V) G) (bool, SetDiff[generichelper.Tuple2[K, V]], error) {
+	if first == nil || second == nil {
+		return false, SetDiff[generichelper.Tuple2[K, V]]{}, errorhelper.CallerError(ErrNilSec2)
+	}
+	if key == nil {
+		return false, SetDiff[generichelper.Tuple2[K, V]]{}, errorhelper.CallerError(ErrNilSelector)
+	}
+	d := unorderedDiff(tupleSeq(first), tupleSeq(second), tupleKey(key), false)
+	return d.Empty(), d, nil
+}
+
+func identity[V any](v V) V {
+	return v
+}
+
+func tupleKey[K, V, G any](key func(K, V) G) func(generichelper.Tuple2[K, V]) G {
+	return func(t generichelper.Tuple2[K, V]) G {
+		return key(t.Item1, t.Item2)
+	}
+}
+
+// unorderedDiff matches values of 'first' against values of 'second' by their keys.
+// If 'set', repeated keys in each sequence are ignored.
+func unorderedDiff[V any, K comparable](first, second iter.Seq[V], key func(V) K, set bool) SetDiff[V] {
+	var ss []V
+	// unmatched holds indexes in 'ss' of not yet matched values of 'second' by their keys
+	unmatched := make(map[K][]int)
+	for v := range second {
+		k := key(v)
+		if set {
+			if _, ok := unmatched[k]; ok {
+				continue
+			}
+		}
+		unmatched[k] = append(unmatched[k], len(ss))
+		ss = append(ss, v)
+	}
+	var d SetDiff[V]
+	matched := make([]bool, len(ss))
+	var seen map[K]bool
+	if set {
+		seen = make(map[K]bool)
+	}
+	for v := range first {
+		k := key(v)
+		if set {
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+		}
+		if ii := unmatched[k]; len(ii) > 0 {
+			matched[ii[0]] = true
+			unmatched[k] = ii[1:]
+			continue
+		}
+		d.Missing = append(d.Missing, v)
+	}
+	for i, v := range ss {
+		if !matched[i] {
+			d.Extra = append(d.Extra, v)
+		}
+	}
+	return d
+}
@@ -0,0 +1,162 @@
+package iterhelper
+
+import (
+	"errors"
+	"iter"
+	"reflect"
+	"testing"
+
+	"github.com/solsw/generichelper"
+)
+
+func TestSetEqual_int(t *testing.T) {
+	type args struct {
+		first  iter.Seq[int]
+		second iter.Seq[int]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        bool
+		wantDiff    SetDiff[int]
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilFirst",
+			args:        args{first: nil, second: Var(1)},
+			wantErr:     true,
+			expectedErr: ErrNilSec,
+		},
+		{name: "EmptyEmpty",
+			args: args{first: Empty[int](), second: Empty[int]()},
+			want: true,
+		},
+		{name: "Reordered",
+			args: args{first: Var(1, 2, 3), second: Var(3, 1, 2)},
+			want: true,
+		},
+		{name: "Repeated",
+			args: args{first: Var(1, 2, 2, 3), second: Var(3, 1, 2, 1)},
+			want: true,
+		},
+		{name: "Different",
+			args:     args{first: Var(1, 2, 3, 4, 4), second: Var(5, 3, 1, 6, 5)},
+			want:     false,
+			wantDiff: SetDiff[int]{Missing: []int{2, 4}, Extra: []int{5, 6}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotDiff, err := SetEqual(tt.args.first, tt.args.second)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetEqual() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("SetEqual() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if got != tt.want || !reflect.DeepEqual(gotDiff, tt.wantDiff) {
+				t.Errorf("SetEqual() = %v, %+v, want %v, %+v", got, gotDiff, tt.want, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestMultisetEqual_int(t *testing.T) {
+	tests := []struct {
+		name     string
+		first    iter.Seq[int]
+		second   iter.Seq[int]
+		want     bool
+		wantDiff SetDiff[int]
+	}{
+		{name: "Reordered",
+			first:  Var(1, 2, 2, 3),
+			second: Var(2, 3, 2, 1),
+			want:   true,
+		},
+		{name: "DifferentCounts",
+			first:    Var(1, 2, 2, 3, 3),
+			second:   Var(3, 1, 2, 1),
+			want:     false,
+			wantDiff: SetDiff[int]{Missing: []int{2, 3}, Extra: []int{1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotDiff, err := MultisetEqual(tt.first, tt.second)
+			if err != nil {
+				t.Fatalf("MultisetEqual() error = %v", err)
+			}
+			if got != tt.want || !reflect.DeepEqual(gotDiff, tt.wantDiff) {
+				t.Errorf("MultisetEqual() = %v, %+v, want %v, %+v", got, gotDiff, tt.want, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestSetEqualBy_slice(t *testing.T) {
+	key := func(s []int) int { return len(s) }
+	got, gotDiff, err := SetEqualBy(Var([]int{1}, []int{1, 2}), Var([]int{3, 4}, []int{5}, []int{6}), key)
+	if err != nil || !got || !gotDiff.Empty() {
+		t.Errorf("SetEqualBy() = %v, %+v, %v, want %v", got, gotDiff, err, true)
+	}
+	if _, _, err := SetEqualBy(Var([]int{1}), Var([]int{1}), (func([]int) int)(nil)); !errors.Is(err, ErrNilSelector) {
+		t.Errorf("SetEqualBy() error = %v, expectedErr %v", err, ErrNilSelector)
+	}
+}
+
+func TestMultisetEqualBy_slice(t *testing.T) {
+	key := func(s []int) int { return len(s) }
+	got, gotDiff, err := MultisetEqualBy(Var([]int{1}, []int{1, 2}), Var([]int{3, 4}, []int{5}, []int{6}), key)
+	if err != nil {
+		t.Fatalf("MultisetEqualBy() error = %v", err)
+	}
+	wantDiff := SetDiff[[]int]{Extra: [][]int{{6}}}
+	if got || !reflect.DeepEqual(gotDiff, wantDiff) {
+		t.Errorf("MultisetEqualBy() = %v, %+v, want %v, %+v", got, gotDiff, false, wantDiff)
+	}
+}
+
+func TestSetEqual2_int_string(t *testing.T) {
+	got, gotDiff, err := SetEqual2(sec2_int_string(3),
+		Var2Tuple(generichelper.NewTuple2(2, "2"), generichelper.NewTuple2(0, "0"), generichelper.NewTuple2(1, "one")))
+	if err != nil {
+		t.Fatalf("SetEqual2() error = %v", err)
+	}
+	wantDiff := SetDiff[generichelper.Tuple2[int, string]]{
+		Missing: []generichelper.Tuple2[int, string]{generichelper.NewTuple2(1, "1")},
+		Extra:   []generichelper.Tuple2[int, string]{generichelper.NewTuple2(1, "one")},
+	}
+	if got || !reflect.DeepEqual(gotDiff, wantDiff) {
+		t.Errorf("SetEqual2() = %v, %+v, want %v, %+v", got, gotDiff, false, wantDiff)
+	}
+}
+
+func TestMultisetEqual2_int_string(t *testing.T) {
+	got, _, err := MultisetEqual2(sec2_int_string(3),
+		Var2Tuple(generichelper.NewTuple2(2, "2"), generichelper.NewTuple2(0, "0"), generichelper.NewTuple2(1, "1")))
+	if err != nil || !got {
+		t.Errorf("MultisetEqual2() = %v, %v, want %v", got, err, true)
+	}
+}
+
+func TestSetEqualBy2_MultisetEqualBy2(t *testing.T) {
+	byKey := func(k int, _ string) int { return k }
+	first := sec2_int_string(2)
+	second := Var2Tuple(generichelper.NewTuple2(1, "a"), generichelper.NewTuple2(0, "b"), generichelper.NewTuple2(0, "c"))
+	got, _, err := SetEqualBy2(first, second, byKey)
+	if err != nil || !got {
+		t.Errorf("SetEqualBy2() = %v, %v, want %v", got, err, true)
+	}
+	got, gotDiff, err := MultisetEqualBy2(first, second, byKey)
+	wantDiff := SetDiff[generichelper.Tuple2[int, string]]{
+		Extra: []generichelper.Tuple2[int, string]{generichelper.NewTuple2(0, "c")},
+	}
+	if err != nil || got || !reflect.DeepEqual(gotDiff, wantDiff) {
+		t.Errorf("MultisetEqualBy2() = %v, %+v, %v, want %v, %+v", got, gotDiff, err, false, wantDiff)
+	}
+}
@@ -9,6 +9,8 @@ import (
 )
 
 // Var returns an [iterator] over the [variadic] parameters/values.
+// Use [VarSized] to get a slice-backed [Sequence],
+// which [EqualSequence] and [EqualEqSequence] compare without [iter.Pull].
 //
 // [iterator]: https://pkg.go.dev/iter#Seq
 // [variadic]: https://go.dev/ref/spec#Function_types
@@ -54,3 +56,18 @@ func Var2[K, V any](vv ...any) (iter.Seq2[K, V], error) {
 	}
 	return Var2Tuple(tt...), nil
 }
+
+// VarSized returns a [Sized] over the [variadic] parameters/values.
+// The [Sized] is backed by the slice of the parameters.
+//
+// [variadic]: https://go.dev/ref/spec#Function_types
+func VarSized[V any](vv ...V) Sized[V] {
+	return SliceSized(vv)
+}
+
+// Var2TupleSized returns a [Sized2] over the [variadic] tuples of values.
+//
+// [variadic]: https://go.dev/ref/spec#Function_types
+func Var2TupleSized[K, V any](tt ...generichelper.Tuple2[K, V]) Sized2[K, V] {
+	return NewSized2(Var2Tuple(tt...), len(tt))
+}
@@ -0,0 +1,107 @@
+package iterhelper
+
+import (
+	"io/fs"
+	"iter"
+	"path"
+	"sync/atomic"
+
+	"github.com/solsw/errorhelper"
+)
+
+// WalkOptions represents parameters of [WalkDir].
+type WalkOptions struct {
+	// MaxDepth, if positive, is the maximum depth of yielded entries (the root has depth 0).
+	// Directories at MaxDepth are yielded but not read.
+	MaxDepth int
+	// Pattern, if not empty, is matched by [path.Match] against entry names.
+	// Entries with not matching names are not yielded, but directories are walked anyway.
+	Pattern string
+}
+
+// WalkEntry is a directory entry yielded by [WalkDir].
+type WalkEntry struct {
+	// DirEntry is nil if the root cannot be accessed (see Err).
+	fs.DirEntry
+	// Depth is the depth of the entry, the root has depth 0.
+	Depth int
+	// Err, if not nil, is the error occurred while accessing the root or reading the directory.
+	Err error
+	// skip is nil for non-directory entries
+	skip *atomic.Bool
+}
+
+// SkipDir makes [WalkDir] not walk the directory's contents.
+// It has no effect if the entry is not a directory or if it is called after the next entry is pulled.
+func (e WalkEntry) SkipDir() {
+	if e.skip != nil {
+		e.skip.Store(true)
+	}
+}
+
+// WalkDir returns an [iterator] over the file tree rooted at 'root' in 'fsys'.
+// The [iterator] yields slash-separated paths (see [fs.ValidPath]) and the corresponding entries
+// in lexical order, a directory before its contents, just like [fs.WalkDir] does.
+// If the root cannot be accessed, a single entry with nil DirEntry and non-nil Err is yielded.
+// If a directory cannot be read, its entry is yielded once more with non-nil Err.
+// Such entries are yielded regardless of 'options' Pattern.
+// The consumer may call [WalkEntry.SkipDir] to skip a directory's contents.
+// The tree is read as the entries are pulled, so changes to 'fsys' during the iteration may be observed.
+//
+// [iterator]: https://pkg.go.dev/iter#Seq2
+func WalkDir(fsys fs.FS, root string, options WalkOptions) (iter.Seq2[string, WalkEntry], error) {
+	if fsys == nil {
+		return nil, errorhelper.CallerError(ErrNilFS)
+	}
+	if options.MaxDepth < 0 {
+		return nil, errorhelper.CallerError(ErrNegativeCount)
+	}
+	if options.Pattern != "" {
+		if _, err := path.Match(options.Pattern, ""); err != nil {
+			return nil, errorhelper.CallerError(err)
+		}
+	}
+	return func(yield func(string, WalkEntry) bool) {
+			info, err := fs.Stat(fsys, root)
+			if err != nil {
+				yield(root, WalkEntry{Err: errorhelper.CallerError(err)})
+				return
+			}
+			walkDir(fsys, root, fs.FileInfoToDirEntry(info), 0, options, yield)
+		},
+		nil
+}
+
+// walkDir yields 'd' located at 'name' and, if it is a directory, walks its contents.
+// It returns false if the iteration has been stopped by the consumer.
+func walkDir(fsys fs.FS, name string, d fs.DirEntry, depth int, options WalkOptions,
+	yield func(string, WalkEntry) bool) bool {
+	e := WalkEntry{DirEntry: d, Depth: depth}
+	if d.IsDir() {
+		e.skip = new(atomic.Bool)
+	}
+	// 'options' Pattern has been validated by WalkDir
+	if matched, _ := path.Match(options.Pattern, d.Name()); (options.Pattern == "" || matched) && !yield(name, e) {
+		return false
+	}
+	if !d.IsDir() || e.skip.Load() || (options.MaxDepth > 0 && depth == options.MaxDepth) {
+		return true
+	}
+	dd, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		e.Err = errorhelper.CallerError(err)
+		if !yield(name, e) {
+			return false
+		}
+		// the entries read before the error, if any, are walked unless skipped
+		if e.skip.Load() {
+			return true
+		}
+	}
+	for _, d1 := range dd {
+		if !walkDir(fsys, path.Join(name, d1.Name()), d1, depth+1, options, yield) {
+			return false
+		}
+	}
+	return true
+}
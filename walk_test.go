@@ -0,0 +1,248 @@
+package iterhelper
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"iter"
+	"path"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+)
+
+var walkFS = fstest.MapFS{
+	"a.go":          {},
+	"b.txt":         {},
+	"d/c.go":        {},
+	"d/e/f.go":      {},
+	"d/e/g.txt":     {},
+	"d/h/i.go":      {},
+	"empty":         {Mode: fs.ModeDir},
+	"z/deep/x/y.go": {},
+}
+
+// failingDirFS fails to read directory 'bad' after reading its first entry.
+type failingDirFS struct {
+	fstest.MapFS
+	bad string
+}
+
+func (f failingDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	dd, err := f.MapFS.ReadDir(name)
+	if name == f.bad {
+		return dd[:1], ErrTestError
+	}
+	return dd, err
+}
+
+func walkPaths(t *testing.T, seq2 iter.Seq2[string, WalkEntry]) []string {
+	t.Helper()
+	var pp []string
+	for p, e := range seq2 {
+		if e.Err != nil {
+			t.Fatalf("WalkDir() %q error = %v", p, e.Err)
+		}
+		pp = append(pp, p)
+	}
+	return pp
+}
+
+func TestWalkDir(t *testing.T) {
+	tests := []struct {
+		name        string
+		fsys        fs.FS
+		root        string
+		options     WalkOptions
+		want        []string
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "NilFS",
+			fsys:        nil,
+			root:        ".",
+			wantErr:     true,
+			expectedErr: ErrNilFS,
+		},
+		{name: "NegativeMaxDepth",
+			fsys:        walkFS,
+			root:        ".",
+			options:     WalkOptions{MaxDepth: -1},
+			wantErr:     true,
+			expectedErr: ErrNegativeCount,
+		},
+		{name: "BadPattern",
+			fsys:        walkFS,
+			root:        ".",
+			options:     WalkOptions{Pattern: "["},
+			wantErr:     true,
+			expectedErr: path.ErrBadPattern,
+		},
+		{name: "All",
+			fsys: walkFS,
+			root: ".",
+			want: []string{".", "a.go", "b.txt", "d", "d/c.go", "d/e", "d/e/f.go", "d/e/g.txt", "d/h", "d/h/i.go",
+				"empty", "z", "z/deep", "z/deep/x", "z/deep/x/y.go"},
+		},
+		{name: "Subtree",
+			fsys: walkFS,
+			root: "d/e",
+			want: []string{"d/e", "d/e/f.go", "d/e/g.txt"},
+		},
+		{name: "File",
+			fsys: walkFS,
+			root: "d/c.go",
+			want: []string{"d/c.go"},
+		},
+		{name: "MaxDepth",
+			fsys:    walkFS,
+			root:    ".",
+			options: WalkOptions{MaxDepth: 2},
+			want:    []string{".", "a.go", "b.txt", "d", "d/c.go", "d/e", "d/h", "empty", "z", "z/deep"},
+		},
+		{name: "Pattern",
+			fsys:    walkFS,
+			root:    ".",
+			options: WalkOptions{Pattern: "*.go"},
+			want:    []string{"a.go", "d/c.go", "d/e/f.go", "d/h/i.go", "z/deep/x/y.go"},
+		},
+		{name: "PatternAndMaxDepth",
+			fsys:    walkFS,
+			root:    "d",
+			options: WalkOptions{MaxDepth: 1, Pattern: "[c-e]*"},
+			want:    []string{"d", "d/c.go", "d/e"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WalkDir(tt.fsys, tt.root, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WalkDir() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("WalkDir() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if pp := walkPaths(t, got); !slices.Equal(pp, tt.want) {
+				t.Errorf("WalkDir() = %q, want %q", pp, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalkDir_depth(t *testing.T) {
+	got, _ := WalkDir(walkFS, "z", WalkOptions{})
+	var dd []int
+	for _, e := range got {
+		dd = append(dd, e.Depth)
+	}
+	if want := []int{0, 1, 2, 3}; !slices.Equal(dd, want) {
+		t.Errorf("WalkDir() depths = %v, want %v", dd, want)
+	}
+}
+
+func TestWalkDir_skipDir(t *testing.T) {
+	got, _ := WalkDir(walkFS, ".", WalkOptions{})
+	var pp []string
+	for p, e := range got {
+		pp = append(pp, p)
+		if e.Name() == "d" || e.Name() == "deep" || e.Name() == "a.go" {
+			// SkipDir on a file has no effect
+			e.SkipDir()
+		}
+	}
+	if want := []string{".", "a.go", "b.txt", "d", "empty", "z", "z/deep"}; !slices.Equal(pp, want) {
+		t.Errorf("WalkDir() = %q, want %q", pp, want)
+	}
+}
+
+func TestWalkDir_break(t *testing.T) {
+	got, _ := WalkDir(walkFS, ".", WalkOptions{})
+	var pp []string
+	for p := range got {
+		pp = append(pp, p)
+		if p == "d/e" {
+			break
+		}
+	}
+	if want := []string{".", "a.go", "b.txt", "d", "d/c.go", "d/e"}; !slices.Equal(pp, want) {
+		t.Errorf("WalkDir() = %q, want %q", pp, want)
+	}
+}
+
+func TestWalkDir_errors(t *testing.T) {
+	got, _ := WalkDir(walkFS, "missing", WalkOptions{})
+	var pp []string
+	for p, e := range got {
+		pp = append(pp, p)
+		if !errors.Is(e.Err, fs.ErrNotExist) || e.DirEntry != nil {
+			t.Errorf("WalkDir() %q = %v, %v, want ErrNotExist", p, e.DirEntry, e.Err)
+		}
+	}
+	if want := []string{"missing"}; !slices.Equal(pp, want) {
+		t.Errorf("WalkDir() = %q, want %q", pp, want)
+	}
+
+	for _, skip := range []bool{false, true} {
+		got, _ = WalkDir(failingDirFS{MapFS: walkFS, bad: "d"}, ".", WalkOptions{Pattern: "*.go"})
+		pp = nil
+		var errPath string
+		for p, e := range got {
+			pp = append(pp, p)
+			if e.Err != nil {
+				if !errors.Is(e.Err, ErrTestError) || !e.IsDir() {
+					t.Errorf("WalkDir() %q error = %v, expectedErr %v", p, e.Err, ErrTestError)
+				}
+				errPath = p
+				if skip {
+					e.SkipDir()
+				}
+			}
+		}
+		want := []string{"a.go", "d", "d/c.go", "z/deep/x/y.go"}
+		if skip {
+			want = slices.Delete(want, 2, 3)
+		}
+		if errPath != "d" || !slices.Equal(pp, want) {
+			t.Errorf("WalkDir(skip: %v) = %q, error at %q, want %q, error at %q", skip, pp, errPath, want, "d")
+		}
+	}
+}
+
+func TestWalkDir_ForEachConcurrent2(t *testing.T) {
+	got, _ := WalkDir(walkFS, ".", WalkOptions{Pattern: "*.go"})
+	var n int64
+	err := ForEachConcurrent2(context.Background(), got, func(_ context.Context, p string, e WalkEntry) error {
+		if e.Err != nil {
+			return e.Err
+		}
+		if _, err := fs.Stat(walkFS, p); err != nil {
+			return err
+		}
+		atomic.AddInt64(&n, 1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachConcurrent2() error = %v", err)
+	}
+	if n != 5 {
+		t.Errorf("ForEachConcurrent2() visited %v files, want %v", n, 5)
+	}
+}
+
+func TestWalkDir_ForEach2(t *testing.T) {
+	got, _ := WalkDir(walkFS, "d", WalkOptions{})
+	err := ForEach2(context.Background(), got, func(p string, e WalkEntry) error {
+		if p == "d/e/g.txt" {
+			return ErrTestError
+		}
+		return nil
+	})
+	if !errors.Is(err, ErrTestError) {
+		t.Errorf("ForEach2() error = %v, expectedErr %v", err, ErrTestError)
+	}
+}